@@ -0,0 +1,98 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/openfaas/faas-cli/config"
+	"github.com/openfaas/faas-cli/oidc"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	faasCmd.AddCommand(authCmd)
+}
+
+var authCmd = &cobra.Command{
+	Use:   `auth`,
+	Short: "Inspect saved credentials",
+	Long:  `Inspect the credentials saved by "faas-cli login" and when their tokens expire`,
+}
+
+// authSummary describes a saved login without showing its secret
+type authSummary struct {
+	Gateway string
+	Type    string
+	Subject string
+	Issuer  string
+	Expiry  time.Time
+}
+
+// summariseAuth describes an auth entry. The token is the access token in
+// use for the entry, which may have been minted or refreshed since the entry
+// was saved.
+func summariseAuth(authConfig config.AuthConfig, token string) authSummary {
+	summary := authSummary{
+		Gateway: authConfig.Gateway,
+		Type:    string(authConfig.Auth),
+		Issuer:  oidc.GetOption(authConfig, oidc.OptionIssuer),
+	}
+
+	if authConfig.Auth == config.BasicAuthType {
+		if user, _, err := config.DecodeAuth(authConfig.Token); err == nil {
+			summary.Subject = user
+		}
+		return summary
+	}
+
+	if grant := oidc.GetOption(authConfig, oidc.OptionGrant); len(grant) > 0 {
+		summary.Type = fmt.Sprintf("%s (%s)", authConfig.Auth, grant)
+		summary.Subject = oidc.GetOption(authConfig, oidc.OptionClientID)
+	} else if len(summary.Issuer) > 0 {
+		summary.Type = fmt.Sprintf("%s (oidc)", authConfig.Auth)
+	}
+
+	summary.Expiry = oidc.TokenFromAuthConfig(authConfig).Expiry
+
+	if jwt, err := unmarshalJwt(token); err == nil {
+		for _, claim := range []string{"preferred_username", "email", "sub"} {
+			if v, ok := jwt.Payload[claim].(string); ok && len(v) > 0 {
+				summary.Subject = v
+				break
+			}
+		}
+
+		if iss, ok := jwt.Payload["iss"].(string); ok && len(summary.Issuer) == 0 {
+			summary.Issuer = iss
+		}
+
+		if exp, ok := jwt.Payload["exp"].(float64); ok {
+			summary.Expiry = time.Unix(int64(exp), 0)
+		}
+	}
+
+	return summary
+}
+
+// formatExpiry prints when a token expires relative to now
+func formatExpiry(expiry, now time.Time) string {
+	if expiry.IsZero() {
+		return "-"
+	}
+
+	if !expiry.After(now) {
+		return fmt.Sprintf("%s (expired)", expiry.Local().Format(time.RFC3339))
+	}
+
+	return fmt.Sprintf("%s (in %s)", expiry.Local().Format(time.RFC3339), expiry.Sub(now).Round(time.Second))
+}
+
+func valueOrDash(value string) string {
+	if len(value) == 0 {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,58 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/openfaas/faas-cli/config"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	authCmd.AddCommand(authListCmd)
+}
+
+var authListCmd = &cobra.Command{
+	Use:     `list`,
+	Aliases: []string{"ls"},
+	Short:   "List the gateways with saved credentials",
+	Long: `List the gateways with saved credentials, along with the auth type, user or
+subject, and when the saved token expires. Logins which mint or refresh their
+tokens show the expiry of the saved token, see "faas-cli auth status".`,
+	Example: `  faas-cli auth list`,
+	RunE:    runAuthList,
+}
+
+func runAuthList(cmd *cobra.Command, args []string) error {
+	authConfigs, err := config.ListAuthConfigs()
+	if err != nil {
+		return err
+	}
+
+	if len(authConfigs) == 0 {
+		fmt.Println("No saved credentials, log in with \"faas-cli login\".")
+		return nil
+	}
+
+	fmt.Print(renderAuthList(authConfigs, time.Now()))
+	return nil
+}
+
+func renderAuthList(authConfigs []config.AuthConfig, now time.Time) string {
+	var b bytes.Buffer
+	w := tabwriter.NewWriter(&b, 0, 0, 1, ' ', 0)
+	fmt.Fprintln(w, "GATEWAY\tAUTH\tUSER\tEXPIRES")
+
+	for _, authConfig := range authConfigs {
+		summary := summariseAuth(authConfig, authConfig.Token)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", summary.Gateway, summary.Type, valueOrDash(summary.Subject), formatExpiry(summary.Expiry, now))
+	}
+
+	w.Flush()
+	return b.String()
+}
@@ -0,0 +1,128 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/openfaas/faas-cli/config"
+	"github.com/openfaas/faas-cli/oidc"
+	"github.com/openfaas/faas-cli/proxy"
+	"github.com/spf13/cobra"
+)
+
+var authWarnBefore time.Duration
+
+func init() {
+	authStatusCmd.Flags().StringVarP(&gateway, "gateway", "g", defaultGateway, "Gateway URL starting with http(s)://")
+	authStatusCmd.Flags().BoolVar(&tlsInsecure, "tls-no-verify", false, "Disable TLS validation")
+	authStatusCmd.Flags().DurationVar(&authWarnBefore, "warn-before", 10*time.Minute, "Warn when the token expires within this duration")
+
+	authCmd.AddCommand(authStatusCmd)
+}
+
+var authStatusCmd = &cobra.Command{
+	Use:   `status [--gateway GATEWAY_URL]`,
+	Short: "Check the saved credentials for a gateway",
+	Long: `Show the saved credentials for a gateway and check that the gateway accepts
+them. Tokens which can't be refreshed or minted again are reported when they
+are about to expire.`,
+	Example: `  faas-cli auth status
+  faas-cli auth status --gateway https://openfaas.example.com --warn-before 1h`,
+	RunE: runAuthStatus,
+}
+
+func runAuthStatus(cmd *cobra.Command, args []string) error {
+	gatewayAddress := getGatewayURL(gateway, defaultGateway, "", os.Getenv(openFaaSURLEnvironment))
+
+	authConfig, err := config.LookupAuthConfig(gatewayAddress)
+	if err != nil {
+		var notFound *config.AuthConfigNotFoundError
+		if errors.Is(err, config.ErrConfigNotFound) || errors.As(err, &notFound) {
+			return fmt.Errorf("not logged in to %s, run \"faas-cli login\"", gatewayAddress)
+		}
+		return err
+	}
+
+	return checkAuthStatus(os.Stdout, gatewayAddress, authConfig, time.Now())
+}
+
+func checkAuthStatus(w io.Writer, gatewayAddress string, authConfig config.AuthConfig, now time.Time) error {
+	renewable := oidc.IsMachineLogin(authConfig) || oidc.IsRefreshable(authConfig)
+
+	var (
+		clientAuth proxy.ClientAuth
+		token      string
+		err        error
+	)
+
+	switch {
+	case oidc.IsMachineLogin(authConfig):
+		var machineAuth *oidc.SourceAuth
+		if machineAuth, err = oidc.NewMachineAuth(authConfig); err == nil {
+			token, err = machineAuth.Token()
+			clientAuth = machineAuth
+		}
+	case oidc.IsRefreshable(authConfig):
+		tokenAuth := oidc.NewTokenAuth(authConfig, proxy.DefaultAuthTransport)
+		token, err = tokenAuth.Token()
+		clientAuth = tokenAuth
+	default:
+		if authConfig.Auth != config.BasicAuthType {
+			token = authConfig.Token
+		}
+		clientAuth, err = proxy.NewCLIAuth("", gatewayAddress)
+	}
+
+	summary := summariseAuth(authConfig, token)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(tw, "Gateway:\t%s\n", summary.Gateway)
+	fmt.Fprintf(tw, "Auth:\t%s\n", summary.Type)
+	fmt.Fprintf(tw, "User:\t%s\n", valueOrDash(summary.Subject))
+	if len(summary.Issuer) > 0 {
+		fmt.Fprintf(tw, "Issuer:\t%s\n", summary.Issuer)
+	}
+	if authConfig.Auth != config.BasicAuthType {
+		fmt.Fprintf(tw, "Expires:\t%s\n", formatExpiry(summary.Expiry, now))
+	}
+	tw.Flush()
+
+	if err != nil {
+		return fmt.Errorf("unable to get a token for %s: %w", gatewayAddress, err)
+	}
+
+	if !summary.Expiry.IsZero() && !renewable {
+		if !summary.Expiry.After(now) {
+			return fmt.Errorf("the token for %s has expired, run \"faas-cli login\"", gatewayAddress)
+		}
+		if summary.Expiry.Sub(now) < authWarnBefore {
+			fmt.Fprintf(w, "WARNING! The token expires in %s, run \"faas-cli login\" to renew it.\n", summary.Expiry.Sub(now).Round(time.Second))
+		}
+	}
+
+	if msg := checkTLSInsecure(gatewayAddress, tlsInsecure); len(msg) > 0 {
+		fmt.Fprintln(w, msg)
+	}
+
+	transport := GetDefaultCLITransport(tlsInsecure, &commandTimeout)
+	client, err := proxy.NewClient(clientAuth, gatewayAddress, transport, &commandTimeout)
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.GetSystemInfo(context.Background()); err != nil {
+		return fmt.Errorf("the gateway did not accept the credentials: %w", err)
+	}
+
+	fmt.Fprintln(w, "The gateway accepted the credentials.")
+
+	return nil
+}
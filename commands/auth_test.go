@@ -0,0 +1,189 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openfaas/faas-cli/config"
+	"github.com/openfaas/faas-cli/test"
+)
+
+func makeTestJwt(t *testing.T, claims map[string]interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(header) + "." +
+		base64.RawURLEncoding.EncodeToString(payload) + ".signature"
+}
+
+func Test_summariseAuth_Basic(t *testing.T) {
+	summary := summariseAuth(config.AuthConfig{
+		Gateway: "http://127.0.0.1:8080",
+		Auth:    config.BasicAuthType,
+		Token:   config.EncodeAuth("admin", "pass"),
+	}, "")
+
+	if summary.Type != "basic" || summary.Subject != "admin" || !summary.Expiry.IsZero() {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+}
+
+func Test_summariseAuth_Jwt(t *testing.T) {
+	exp := time.Now().Add(time.Hour).Truncate(time.Second)
+	token := makeTestJwt(t, map[string]interface{}{
+		"sub":                "1234",
+		"preferred_username": "alex",
+		"iss":                "https://issuer.example.com",
+		"exp":                exp.Unix(),
+	})
+
+	summary := summariseAuth(config.AuthConfig{
+		Gateway: "https://openfaas.example.com",
+		Auth:    config.Oauth2AuthType,
+		Token:   token,
+	}, token)
+
+	if summary.Subject != "alex" {
+		t.Errorf("want subject alex, got: %q", summary.Subject)
+	}
+	if summary.Issuer != "https://issuer.example.com" {
+		t.Errorf("want issuer from the token, got: %q", summary.Issuer)
+	}
+	if !summary.Expiry.Equal(exp) {
+		t.Errorf("want expiry %s, got: %s", exp, summary.Expiry)
+	}
+}
+
+func Test_renderAuthList(t *testing.T) {
+	now := time.Now()
+	token := makeTestJwt(t, map[string]interface{}{"sub": "ci-bot", "exp": now.Add(-time.Minute).Unix()})
+
+	out := renderAuthList([]config.AuthConfig{
+		{Gateway: "http://127.0.0.1:8080", Auth: config.BasicAuthType, Token: config.EncodeAuth("admin", "pass")},
+		{Gateway: "https://openfaas.example.com", Auth: config.Oauth2AuthType, Token: token},
+	}, now)
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("want header and 2 rows, got:\n%s", out)
+	}
+
+	if !strings.Contains(lines[1], "http://127.0.0.1:8080") || !strings.Contains(lines[1], "admin") {
+		t.Errorf("unexpected row for basic auth: %q", lines[1])
+	}
+	if !strings.Contains(lines[2], "ci-bot") || !strings.Contains(lines[2], "(expired)") {
+		t.Errorf("unexpected row for oauth2: %q", lines[2])
+	}
+}
+
+func Test_checkAuthStatus_Basic(t *testing.T) {
+	t.Setenv(config.ConfigLocationEnv, t.TempDir())
+
+	s := test.MockHttpServer(t, []test.Request{
+		{
+			Method:             http.MethodGet,
+			Uri:                "/system/info",
+			ResponseStatusCode: http.StatusOK,
+			ResponseBody:       `{"provider":{"provider":"faas-netes"}}`,
+		},
+	})
+	defer s.Close()
+
+	authConfig := config.AuthConfig{Gateway: s.URL, Auth: config.BasicAuthType, Token: config.EncodeAuth("admin", "pass")}
+	if err := config.UpdateAuthConfig(authConfig); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := checkAuthStatus(&out, s.URL, authConfig, time.Now()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !strings.Contains(out.String(), "admin") || !strings.Contains(out.String(), "accepted") {
+		t.Errorf("unexpected output: %q", out.String())
+	}
+}
+
+func Test_checkAuthStatus_ExpiredToken(t *testing.T) {
+	now := time.Now()
+	token := makeTestJwt(t, map[string]interface{}{"sub": "alex", "exp": now.Add(-time.Minute).Unix()})
+	authConfig := config.AuthConfig{Gateway: "http://127.0.0.1:8080", Auth: config.Oauth2AuthType, Token: token}
+
+	var out bytes.Buffer
+	err := checkAuthStatus(&out, "http://127.0.0.1:8080", authConfig, now)
+	if err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Fatalf("want expired error, got: %v", err)
+	}
+}
+
+func Test_checkAuthStatus_WarnsBeforeExpiry(t *testing.T) {
+	s := test.MockHttpServer(t, []test.Request{
+		{
+			Method:             http.MethodGet,
+			Uri:                "/system/info",
+			ResponseStatusCode: http.StatusOK,
+			ResponseBody:       `{}`,
+		},
+	})
+	defer s.Close()
+
+	defer func(d time.Duration) { authWarnBefore = d }(authWarnBefore)
+	authWarnBefore = 10 * time.Minute
+
+	now := time.Now()
+	token := makeTestJwt(t, map[string]interface{}{"sub": "alex", "exp": now.Add(5 * time.Minute).Unix()})
+	authConfig := config.AuthConfig{Gateway: s.URL, Auth: config.Oauth2AuthType, Token: token}
+
+	t.Setenv(config.ConfigLocationEnv, t.TempDir())
+	if err := config.UpdateAuthConfig(authConfig); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := checkAuthStatus(&out, s.URL, authConfig, now); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !strings.Contains(out.String(), "WARNING") {
+		t.Errorf("want expiry warning, got: %q", out.String())
+	}
+}
+
+func Test_logoutFromAll(t *testing.T) {
+	t.Setenv(config.ConfigLocationEnv, t.TempDir())
+
+	for _, gw := range []string{"http://127.0.0.1:8080", "https://openfaas.example.com"} {
+		if err := config.UpdateAuthConfig(config.AuthConfig{Gateway: gw, Auth: config.BasicAuthType, Token: config.EncodeAuth("admin", "pass")}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stdOut := test.CaptureStdout(func() {
+		if err := logoutFromAll(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	if strings.Count(stdOut, "credentials removed for") != 2 {
+		t.Errorf("want 2 gateways logged out, got: %q", stdOut)
+	}
+
+	authConfigs, err := config.ListAuthConfigs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(authConfigs) != 0 {
+		t.Errorf("want no saved credentials, got: %d", len(authConfigs))
+	}
+}
@@ -28,6 +28,7 @@ func Test_build(t *testing.T) {
 
 func Test_preRunBuild_ParallelOverZero(t *testing.T) {
 	buildCmd.ParseFlags([]string{"--parallel=0"})
+	defer func() { parallel = 1 }()
 	got := buildCmd.PreRunE(buildCmd, nil)
 
 	if got == nil {
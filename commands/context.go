@@ -0,0 +1,24 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	faasCmd.AddCommand(contextCmd)
+}
+
+var contextCmd = &cobra.Command{
+	Use:   `context`,
+	Short: "Manage named contexts for gateways",
+	Long: `Manage named contexts stored in the faas-cli config file. A context holds
+a gateway URL, a default namespace and TLS settings, so that they don't need
+to be given on every command.
+
+The current context is used when no --gateway, stack file gateway or
+OPENFAAS_URL is given. Use the global --context flag to pick a different
+context for a single command.`,
+}
@@ -0,0 +1,95 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openfaas/faas-cli/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	contextGateway     string
+	contextNamespace   string
+	contextAuth        string
+	contextTLSInsecure bool
+	contextUse         bool
+)
+
+func init() {
+	contextCreateCmd.Flags().StringVarP(&contextGateway, "gateway", "g", "", "Gateway URL starting with http(s)://")
+	contextCreateCmd.Flags().StringVarP(&contextNamespace, "namespace", "n", "", "Default namespace for functions")
+	contextCreateCmd.Flags().BoolVar(&contextTLSInsecure, "tls-no-verify", false, "Disable TLS validation")
+	contextCreateCmd.Flags().StringVar(&contextAuth, "auth", "", "Gateway URL of the saved login to use, defaults to the gateway")
+	contextCreateCmd.Flags().BoolVar(&contextUse, "use", false, "Make this the current context")
+
+	contextCmd.AddCommand(contextCreateCmd)
+}
+
+var contextCreateCmd = &cobra.Command{
+	Use:   `create NAME --gateway GATEWAY_URL [--namespace NAMESPACE] [--tls-no-verify] [--use]`,
+	Short: "Create or update a context",
+	Long:  `Create a named context, or update it when it already exists`,
+	Example: `  faas-cli context create staging --gateway https://staging.example.com --namespace staging-fn
+  faas-cli context create local --gateway http://127.0.0.1:8080 --use
+  faas-cli context create tunnel --gateway http://127.0.0.1:31112 --auth https://prod.example.com`,
+	PreRunE: preRunContextCreate,
+	RunE:    runContextCreate,
+}
+
+func preRunContextCreate(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("context name required")
+	}
+
+	if len(args) > 1 {
+		return fmt.Errorf("too many values for context name")
+	}
+
+	if len(contextGateway) == 0 {
+		return fmt.Errorf("the --gateway flag is required")
+	}
+
+	return nil
+}
+
+func runContextCreate(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	cliContext := config.Context{
+		Name:        name,
+		Gateway:     normaliseContextURL(contextGateway),
+		Namespace:   contextNamespace,
+		TLSInsecure: contextTLSInsecure,
+	}
+	if len(contextAuth) > 0 {
+		cliContext.Auth = normaliseContextURL(contextAuth)
+	}
+
+	if err := config.UpdateContext(cliContext); err != nil {
+		return err
+	}
+	fmt.Printf("Context %s saved.\n", name)
+
+	if contextUse {
+		if err := config.SetCurrentContext(name); err != nil {
+			return err
+		}
+		fmt.Printf("Switched to context %s.\n", name)
+	}
+
+	return nil
+}
+
+// normaliseContextURL formats a URL in the same way as getGatewayURL, so
+// that it matches the keys used for saved logins.
+func normaliseContextURL(gatewayURL string) string {
+	gatewayURL = strings.ToLower(strings.TrimRight(gatewayURL, "/"))
+	if !strings.HasPrefix(gatewayURL, "http") {
+		gatewayURL = fmt.Sprintf("http://%s", gatewayURL)
+	}
+	return gatewayURL
+}
@@ -0,0 +1,64 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/openfaas/faas-cli/config"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	contextCmd.AddCommand(contextListCmd)
+}
+
+var contextListCmd = &cobra.Command{
+	Use:     `list`,
+	Aliases: []string{"ls"},
+	Short:   "List contexts",
+	Long:    `List the contexts in the config file, the current context is marked with *`,
+	Example: `  faas-cli context list`,
+	RunE:    runContextList,
+}
+
+func runContextList(cmd *cobra.Command, args []string) error {
+	contexts, current, err := config.ListContexts()
+	if err != nil {
+		return err
+	}
+
+	if len(contexts) == 0 {
+		fmt.Println("No contexts found, create one with \"faas-cli context create\".")
+		return nil
+	}
+
+	fmt.Print(renderContextList(contexts, current))
+	return nil
+}
+
+func renderContextList(contexts []config.Context, current string) string {
+	var b bytes.Buffer
+	w := tabwriter.NewWriter(&b, 0, 0, 1, ' ', 0)
+	fmt.Fprintln(w, "CURRENT\tNAME\tGATEWAY\tNAMESPACE\tTLS VERIFY")
+
+	for _, c := range contexts {
+		marker := ""
+		if c.Name == current {
+			marker = "*"
+		}
+
+		namespace := c.Namespace
+		if len(namespace) == 0 {
+			namespace = "-"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\n", marker, c.Name, c.Gateway, namespace, !c.TLSInsecure)
+	}
+
+	w.Flush()
+	return b.String()
+}
@@ -0,0 +1,34 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/openfaas/faas-cli/config"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	contextCmd.AddCommand(contextRemoveCmd)
+}
+
+var contextRemoveCmd = &cobra.Command{
+	Use:     `remove NAME`,
+	Aliases: []string{"rm", "delete"},
+	Short:   "Remove a context",
+	Long:    `Remove a context from the config file, saved logins are kept`,
+	Example: `  faas-cli context remove staging`,
+	PreRunE: preRunContextUse,
+	RunE:    runContextRemove,
+}
+
+func runContextRemove(cmd *cobra.Command, args []string) error {
+	if err := config.RemoveContext(args[0]); err != nil {
+		return err
+	}
+
+	fmt.Printf("Context %s removed.\n", args[0])
+	return nil
+}
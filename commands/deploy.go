@@ -8,13 +8,19 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/openfaas/faas-cli/builder"
+	"github.com/openfaas/faas-cli/history"
 	"github.com/openfaas/faas-cli/proxy"
 	"github.com/openfaas/faas-cli/schema"
 	"github.com/openfaas/faas-cli/util"
+	"github.com/openfaas/faas-cli/versioncontrol"
+	"github.com/openfaas/faas-provider/types"
+	"github.com/openfaas/go-sdk"
 	"github.com/openfaas/go-sdk/stack"
 
 	"github.com/spf13/cobra"
@@ -37,6 +43,12 @@ type DeployFlags struct {
 	secrets                []string
 	labelOpts              []string
 	annotationOpts         []string
+	dryRun                 bool
+	wait                   bool
+	rollbackOnFailure      bool
+	prune                  bool
+	yes                    bool
+	stackName              string
 }
 
 var deployFlags DeployFlags
@@ -75,7 +87,14 @@ func init() {
 	_ = deployCmd.Flags().SetAnnotation("handler", cobra.BashCompSubdirsInDir, []string{})
 	deployCmd.Flags().BoolVar(&readTemplate, "read-template", true, "Read the function's template")
 
-	deployCmd.Flags().DurationVar(&timeoutOverride, "timeout", commandTimeout, "Timeout for any HTTP calls made to the OpenFaaS API.")
+	deployCmd.Flags().DurationVar(&timeoutOverride, "timeout", commandTimeout, "Timeout for any HTTP calls made to the OpenFaaS API, and for the rollout when --wait is given.")
+	deployCmd.Flags().IntVar(&parallel, "parallel", 1, "Deploy functions in parallel to depth specified.")
+	deployCmd.Flags().BoolVar(&deployFlags.wait, "wait", false, "Wait until the new version of each function is ready")
+	deployCmd.Flags().BoolVar(&deployFlags.rollbackOnFailure, "rollback-on-failure", false, "Re-apply the previous version of a function if it does not become ready, requires --wait")
+	deployCmd.Flags().BoolVar(&deployFlags.prune, "prune", false, "Remove functions deployed from this stack which are no longer in the stack file")
+	deployCmd.Flags().BoolVar(&deployFlags.yes, "yes", false, "Answer yes to any confirmation prompts, i.e. from --prune")
+	deployCmd.Flags().StringVar(&deployFlags.stackName, "stack-name", "", "Name of the stack, set as the \""+stackLabel+"\" label on each function, defaults to the path of the stack file within its repository")
+	deployCmd.Flags().BoolVar(&deployFlags.dryRun, "dry-run", false, "Print the changes that would be made to the functions in the stack file, without deploying them")
 
 	deployCmd.Flags().StringVar(&cpuRequest, "cpu-request", "", "Supply the CPU request for the function in Mi (when not using a YAML file)")
 	deployCmd.Flags().StringVar(&cpuLimit, "cpu-limit", "", "Supply the CPU limit for the function in Mi (when not using a YAML file)")
@@ -106,12 +125,24 @@ var deployCmd = &cobra.Command{
 				  [--secret "SECRET_NAME"]
 				  [--tag <sha|branch|describe>]
 				  [--readonly=false]
+				  [--parallel PARALLEL_DEPTH]
+				  [--dry-run]
+				  [--prune [--yes] [--stack-name NAME]]
+				  [--wait [--timeout DURATION] [--rollback-on-failure]]
 				  [--tls-no-verify]`,
 
 	Short: "Deploy OpenFaaS functions",
 	Long: `Deploys OpenFaaS function containers either via the supplied YAML config using
 the "--yaml" flag (which may contain multiple function definitions), or directly
-via flags. Note: --replace and --update are mutually exclusive.`,
+via flags. Note: --replace and --update are mutually exclusive.
+
+Namespaces and secrets listed under the top-level "namespaces" and "secrets"
+sections of the YAML file are created or updated before any function is
+deployed. Secret values are read from "from_file", "from_env", or when
+neither is given, from a file with the secret's name in the .secrets folder.
+
+With --dry-run, the changes are printed instead of deployed, and the command
+exits with status 2 when there are any, in the same way as "faas-cli diff".`,
 	Example: `  faas-cli deploy -f https://domain/path/myfunctions.yml
   faas-cli deploy -f stack.yaml
   faas-cli deploy -f stack.yaml --label canary=true
@@ -123,6 +154,10 @@ via flags. Note: --replace and --update are mutually exclusive.`,
   faas-cli deploy -f stack.yaml --tag sha
   faas-cli deploy -f stack.yaml --tag branch
   faas-cli deploy -f stack.yaml --tag describe
+  faas-cli deploy -f stack.yaml --parallel 4
+  faas-cli deploy -f stack.yaml --dry-run
+  faas-cli deploy -f stack.yaml --prune --dry-run
+  faas-cli deploy -f stack.yaml --wait --timeout 2m --rollback-on-failure
   faas-cli deploy --image=alexellis/faas-url-ping --name=url-ping
   faas-cli deploy --image=my_image --name=my_fn --handler=/path/to/fn/
                   --gateway=http://remote-site.com:8080 --lang=python
@@ -135,6 +170,18 @@ via flags. Note: --replace and --update are mutually exclusive.`,
 func preRunDeploy(cmd *cobra.Command, args []string) error {
 	language, _ = validateLanguageFlag(language)
 
+	if parallel < 1 {
+		return fmt.Errorf("the --parallel flag must be greater than 0")
+	}
+
+	if deployFlags.dryRun && len(yamlFile) == 0 {
+		return fmt.Errorf("the --dry-run flag requires a stack file given with --yaml/-f")
+	}
+
+	if deployFlags.rollbackOnFailure && !deployFlags.wait {
+		return fmt.Errorf("the --rollback-on-failure flag requires --wait")
+	}
+
 	return nil
 }
 
@@ -161,12 +208,23 @@ func runDeployCommand(args []string, image string, fprocess string, functionName
 			parsedServices.Provider.GatewayURL = getGatewayURL(gateway, defaultGateway, parsedServices.Provider.GatewayURL, os.Getenv(openFaaSURLEnvironment))
 			services = *parsedServices
 		}
+
+		deployFlags.stackName = getStackName(deployFlags.stackName, yamlFile)
+	} else if deployFlags.prune {
+		return fmt.Errorf("the --prune flag requires a stack file given with --yaml/-f")
 	}
 
 	transport := GetDefaultCLITransport(tlsInsecure, &timeoutOverride)
 	ctx := context.Background()
 
 	var failedStatusCodes = make(map[string]int)
+	var rolloutErrors []error
+
+	rollout := rolloutOptions{
+		wait:     deployFlags.wait,
+		rollback: deployFlags.rollbackOnFailure,
+		timeout:  timeoutOverride,
+	}
 	if len(services.Functions) > 0 {
 
 		cliAuth, err := proxy.NewCLIAuth(token, services.Provider.GatewayURL)
@@ -179,117 +237,64 @@ func runDeployCommand(args []string, image string, fprocess string, functionName
 			return err
 		}
 
-		for k, function := range services.Functions {
-
-			functionSecrets := deployFlags.secrets
+		specs, err := buildStackSpecs(services, deployFlags, tagMode)
+		if err != nil {
+			return err
+		}
 
-			function.Name = k
-			fmt.Printf("Deploying: %s.\n", function.Name)
+		resources, err := schema.ParseStackResources(yamlFile, envsubst)
+		if err != nil {
+			return err
+		}
 
-			var functionConstraints []string
-			if function.Constraints != nil {
-				functionConstraints = *function.Constraints
-			} else if len(deployFlags.constraints) > 0 {
-				functionConstraints = deployFlags.constraints
+		if len(resources.Namespaces) > 0 || len(resources.Secrets) > 0 {
+			var sdkClient *sdk.Client
+			if len(resources.Namespaces) > 0 {
+				if sdkClient, err = newSDKClient(services.Provider.GatewayURL); err != nil {
+					return err
+				}
 			}
 
-			if len(function.Secrets) > 0 {
-				functionSecrets = util.MergeSlice(function.Secrets, functionSecrets)
+			if err := reconcileStackResources(ctx, sdkClient, proxyClient, resources, deployFlags.dryRun); err != nil {
+				return err
 			}
+		}
 
-			// Check if there is a functionNamespace flag passed, if so, override the namespace value
-			// defined in the stack.yaml
-			function.Namespace = getNamespace(functionNamespace, function.Namespace)
-
-			fileEnvironment, err := readFiles(function.EnvironmentFile)
+		if deployFlags.dryRun {
+			diffs, err := diffStack(ctx, proxyClient, specs, removedStackName(deployFlags.stackName))
 			if err != nil {
 				return err
 			}
 
-			labelMap := map[string]string{}
-			if function.Labels != nil {
-				labelMap = *function.Labels
-			}
+			printFunctionDiffs(os.Stdout, diffs)
 
-			labelArgumentMap, labelErr := util.ParseMap(deployFlags.labelOpts, "label")
-			if labelErr != nil {
-				return fmt.Errorf("error parsing labels: %v", labelErr)
-			}
-
-			allLabels := util.MergeMap(labelMap, labelArgumentMap)
-
-			allEnvironment, envErr := compileEnvironment(deployFlags.envvarOpts, function.Environment, fileEnvironment)
-			if envErr != nil {
-				return envErr
-			}
-
-			if readTemplate {
-				// Get FProcess to use from the ./template/template.yml, if a template is being used
-				if languageExistsNotDockerfile(function.Language) {
-					var fprocessErr error
-
-					function.FProcess, fprocessErr = deriveFprocess(function)
-					if fprocessErr != nil {
-						return fmt.Errorf(`template directory may be missing or invalid, please run "faas-cli template pull"
-Error: %s`, fprocessErr.Error())
-					}
+			if deployFlags.prune {
+				if err := runPrune(ctx, proxyClient, deployFlags); err != nil {
+					return err
 				}
 			}
 
-			functionResourceRequest := proxy.FunctionResourceRequest{
-				Limits:   function.Limits,
-				Requests: function.Requests,
-			}
-
-			var annotations map[string]string
-			if function.Annotations != nil {
-				annotations = *function.Annotations
+			if len(diffs) > 0 {
+				return &driftError{count: len(diffs)}
 			}
+			return nil
+		}
 
-			annotationArgs, annotationErr := util.ParseMap(deployFlags.annotationOpts, "annotation")
-			if annotationErr != nil {
-				return fmt.Errorf("error parsing annotations: %v", annotationErr)
-			}
+		gitSHA := versioncontrol.GetGitSHA()
+		onDeployed := func(spec *proxy.DeployFunctionSpec) {
+			saveRevision(services.Provider.GatewayURL, gitSHA, spec)
+		}
 
-			allAnnotations := util.MergeMap(annotations, annotationArgs)
+		failed, errs := deployStack(ctx, proxyClient, services.Provider.GatewayURL, specs, parallel, rollout, onDeployed)
+		for k, statusCode := range failed {
+			failedStatusCodes[k] = statusCode
+		}
+		rolloutErrors = append(rolloutErrors, errs...)
 
-			branch, sha, err := builder.GetImageTagValues(tagMode, function.Handler)
-			if err != nil {
+		if deployFlags.prune && len(failedStatusCodes) == 0 && len(rolloutErrors) == 0 {
+			if err := runPrune(ctx, proxyClient, deployFlags); err != nil {
 				return err
 			}
-
-			function.Image = schema.BuildImageName(tagMode, function.Image, sha, branch)
-
-			if deployFlags.readOnlyRootFilesystem {
-				function.ReadOnlyRootFilesystem = deployFlags.readOnlyRootFilesystem
-			}
-
-			deploySpec := &proxy.DeployFunctionSpec{
-				FProcess:                function.FProcess,
-				FunctionName:            function.Name,
-				Image:                   function.Image,
-				Language:                function.Language,
-				Replace:                 deployFlags.replace,
-				EnvVars:                 allEnvironment,
-				Constraints:             functionConstraints,
-				Update:                  deployFlags.update,
-				Secrets:                 functionSecrets,
-				Labels:                  allLabels,
-				Annotations:             allAnnotations,
-				FunctionResourceRequest: functionResourceRequest,
-				ReadOnlyRootFilesystem:  function.ReadOnlyRootFilesystem,
-				TLSInsecure:             tlsInsecure,
-				Token:                   token,
-				Namespace:               function.Namespace,
-			}
-
-			if msg := checkTLSInsecure(services.Provider.GatewayURL, deploySpec.TLSInsecure); len(msg) > 0 {
-				fmt.Println(msg)
-			}
-			statusCode := proxyClient.DeployFunction(ctx, deploySpec)
-			if badStatusCode(statusCode) {
-				failedStatusCodes[k] = statusCode
-			}
 		}
 	} else {
 		if len(image) == 0 || len(functionName) == 0 {
@@ -306,10 +311,17 @@ Error: %s`, fprocessErr.Error())
 			return err
 		}
 
+		namespace := getNamespace(functionNamespace, "")
+
+		var previous *types.FunctionStatus
+		if rollout.wait && rollout.rollback {
+			previous = captureFunction(ctx, proxyClient, functionName, namespace)
+		}
+
 		// default to a readable filesystem until we get more input about the expected behavior
 		// and if we want to add another flag for this case
 		defaultReadOnlyRFS := false
-		statusCode, err := deployImage(ctx,
+		statusCode, deployedSpec, err := deployImage(ctx,
 			proxyClient,
 			image,
 			fprocess,
@@ -319,7 +331,7 @@ Error: %s`, fprocessErr.Error())
 			tlsInsecure,
 			defaultReadOnlyRFS,
 			token,
-			functionNamespace,
+			namespace,
 			cpuRequest,
 			cpuLimit,
 			memoryRequest,
@@ -330,6 +342,17 @@ Error: %s`, fprocessErr.Error())
 
 		if badStatusCode(statusCode) {
 			failedStatusCodes[functionName] = statusCode
+		} else {
+			var rolloutErr error
+			if rollout.wait {
+				rolloutErr = awaitRollout(ctx, proxyClient, deployedSpec, previous, rollout)
+			}
+
+			if rolloutErr != nil {
+				rolloutErrors = append(rolloutErrors, rolloutErr)
+			} else {
+				saveRevision(gateway, versioncontrol.GetGitSHA(), deployedSpec)
+			}
 		}
 	}
 
@@ -337,10 +360,228 @@ Error: %s`, fprocessErr.Error())
 		return err
 	}
 
+	if len(rolloutErrors) > 0 {
+		var allErrors []string
+		for _, err := range rolloutErrors {
+			allErrors = append(allErrors, err.Error())
+		}
+		return fmt.Errorf("%s", strings.Join(allErrors, "\n"))
+	}
+
 	return nil
 }
 
-// deployImage deploys a function with the given image
+// saveRevision records a deployment in the local history, a failure to do so
+// is reported but does not fail the deployment.
+func saveRevision(gatewayURL, gitSHA string, spec *proxy.DeployFunctionSpec) {
+	if _, err := history.Record(gatewayURL, *spec, gitSHA); err != nil {
+		fmt.Printf("Unable to save deployment history for %s: %s\n", spec.FunctionName, err)
+	}
+}
+
+// buildStackSpecs builds a deploy spec for every function in the stack file,
+// sorted by function name.
+func buildStackSpecs(services stack.Services, deployFlags DeployFlags, tagMode schema.BuildFormat) ([]*proxy.DeployFunctionSpec, error) {
+	names := make([]string, 0, len(services.Functions))
+	for k := range services.Functions {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	specs := make([]*proxy.DeployFunctionSpec, 0, len(names))
+	for _, k := range names {
+		function := services.Functions[k]
+		function.Name = k
+
+		deploySpec, err := buildDeploySpec(function, deployFlags, tagMode)
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, deploySpec)
+	}
+
+	return specs, nil
+}
+
+// buildDeploySpec merges a function from the stack file with the flags given to
+// deploy to produce the spec that will be sent to the gateway.
+func buildDeploySpec(function stack.Function, deployFlags DeployFlags, tagMode schema.BuildFormat) (*proxy.DeployFunctionSpec, error) {
+	functionSecrets := deployFlags.secrets
+
+	var functionConstraints []string
+	if function.Constraints != nil {
+		functionConstraints = *function.Constraints
+	} else if len(deployFlags.constraints) > 0 {
+		functionConstraints = deployFlags.constraints
+	}
+
+	if len(function.Secrets) > 0 {
+		functionSecrets = util.MergeSlice(function.Secrets, functionSecrets)
+	}
+
+	// Check if there is a functionNamespace flag passed, if so, override the namespace value
+	// defined in the stack.yaml
+	function.Namespace = getNamespace(functionNamespace, function.Namespace)
+
+	fileEnvironment, err := readFiles(function.EnvironmentFile)
+	if err != nil {
+		return nil, err
+	}
+
+	labelMap := map[string]string{}
+	if function.Labels != nil {
+		labelMap = *function.Labels
+	}
+
+	labelArgumentMap, labelErr := util.ParseMap(deployFlags.labelOpts, "label")
+	if labelErr != nil {
+		return nil, fmt.Errorf("error parsing labels: %v", labelErr)
+	}
+
+	allLabels := util.MergeMap(labelMap, labelArgumentMap)
+	if len(deployFlags.stackName) > 0 {
+		allLabels = util.MergeMap(allLabels, map[string]string{stackLabel: deployFlags.stackName})
+	}
+
+	allEnvironment, envErr := compileEnvironment(deployFlags.envvarOpts, function.Environment, fileEnvironment)
+	if envErr != nil {
+		return nil, envErr
+	}
+
+	if readTemplate {
+		// Get FProcess to use from the ./template/template.yml, if a template is being used
+		if languageExistsNotDockerfile(function.Language) {
+			var fprocessErr error
+
+			function.FProcess, fprocessErr = deriveFprocess(function)
+			if fprocessErr != nil {
+				return nil, fmt.Errorf(`template directory may be missing or invalid, please run "faas-cli template pull"
+Error: %s`, fprocessErr.Error())
+			}
+		}
+	}
+
+	functionResourceRequest := proxy.FunctionResourceRequest{
+		Limits:   function.Limits,
+		Requests: function.Requests,
+	}
+
+	var annotations map[string]string
+	if function.Annotations != nil {
+		annotations = *function.Annotations
+	}
+
+	annotationArgs, annotationErr := util.ParseMap(deployFlags.annotationOpts, "annotation")
+	if annotationErr != nil {
+		return nil, fmt.Errorf("error parsing annotations: %v", annotationErr)
+	}
+
+	allAnnotations := util.MergeMap(annotations, annotationArgs)
+
+	branch, sha, err := builder.GetImageTagValues(tagMode, function.Handler)
+	if err != nil {
+		return nil, err
+	}
+
+	function.Image = schema.BuildImageName(tagMode, function.Image, sha, branch)
+
+	if deployFlags.readOnlyRootFilesystem {
+		function.ReadOnlyRootFilesystem = deployFlags.readOnlyRootFilesystem
+	}
+
+	deploySpec := &proxy.DeployFunctionSpec{
+		FProcess:                function.FProcess,
+		FunctionName:            function.Name,
+		Image:                   function.Image,
+		Language:                function.Language,
+		Replace:                 deployFlags.replace,
+		EnvVars:                 allEnvironment,
+		Constraints:             functionConstraints,
+		Update:                  deployFlags.update,
+		Secrets:                 functionSecrets,
+		Labels:                  allLabels,
+		Annotations:             allAnnotations,
+		FunctionResourceRequest: functionResourceRequest,
+		ReadOnlyRootFilesystem:  function.ReadOnlyRootFilesystem,
+		TLSInsecure:             tlsInsecure,
+		Token:                   token,
+		Namespace:               function.Namespace,
+	}
+
+	return deploySpec, nil
+}
+
+// deployStack deploys each spec using a pool of queueDepth workers and returns
+// the status codes of any deployments which failed, keyed by function name,
+// along with any errors from waiting for the rollouts to complete. onDeployed
+// is called for each function which was deployed successfully.
+func deployStack(ctx context.Context, proxyClient *proxy.Client, gatewayURL string, specs []*proxy.DeployFunctionSpec, queueDepth int, rollout rolloutOptions, onDeployed func(*proxy.DeployFunctionSpec)) (map[string]int, []error) {
+	failedStatusCodes := make(map[string]int)
+	rolloutErrors := []error{}
+	var mu sync.Mutex
+
+	wg := sync.WaitGroup{}
+
+	workChannel := make(chan *proxy.DeployFunctionSpec)
+
+	wg.Add(queueDepth)
+	for i := 0; i < queueDepth; i++ {
+		go func(index int) {
+			for deploySpec := range workChannel {
+				if queueDepth > 1 {
+					fmt.Printf("[%d] > Deploying: %s.\n", index, deploySpec.FunctionName)
+				} else {
+					fmt.Printf("Deploying: %s.\n", deploySpec.FunctionName)
+				}
+
+				if msg := checkTLSInsecure(gatewayURL, deploySpec.TLSInsecure); len(msg) > 0 {
+					fmt.Println(msg)
+				}
+
+				var previous *types.FunctionStatus
+				if rollout.wait && rollout.rollback {
+					previous = captureFunction(ctx, proxyClient, deploySpec.FunctionName, deploySpec.Namespace)
+				}
+
+				statusCode := proxyClient.DeployFunction(ctx, deploySpec)
+				if badStatusCode(statusCode) {
+					mu.Lock()
+					failedStatusCodes[deploySpec.FunctionName] = statusCode
+					mu.Unlock()
+					continue
+				}
+
+				if rollout.wait {
+					if err := awaitRollout(ctx, proxyClient, deploySpec, previous, rollout); err != nil {
+						mu.Lock()
+						rolloutErrors = append(rolloutErrors, err)
+						mu.Unlock()
+						continue
+					}
+				}
+
+				if onDeployed != nil {
+					onDeployed(deploySpec)
+				}
+			}
+
+			wg.Done()
+		}(i)
+	}
+
+	for _, deploySpec := range specs {
+		workChannel <- deploySpec
+	}
+
+	close(workChannel)
+
+	wg.Wait()
+
+	return failedStatusCodes, rolloutErrors
+}
+
+// deployImage deploys a function with the given image, and returns the spec
+// which was sent to the gateway
 func deployImage(
 	ctx context.Context,
 	client *proxy.Client,
@@ -357,25 +598,25 @@ func deployImage(
 	cpuLimit string,
 	memoryRequest string,
 	memoryLimit string,
-) (int, error) {
+) (int, *proxy.DeployFunctionSpec, error) {
 
 	var statusCode int
 	readOnlyRFS := deployFlags.readOnlyRootFilesystem || readOnlyRootFilesystem
 	envvars, err := util.ParseMap(deployFlags.envvarOpts, "env")
 	if err != nil {
-		return statusCode, fmt.Errorf("error parsing envvars: %v", err)
+		return statusCode, nil, fmt.Errorf("error parsing envvars: %v", err)
 	}
 
 	labelMap, labelErr := util.ParseMap(deployFlags.labelOpts, "label")
 
 	if labelErr != nil {
-		return statusCode, fmt.Errorf("error parsing labels: %v", labelErr)
+		return statusCode, nil, fmt.Errorf("error parsing labels: %v", labelErr)
 	}
 
 	annotationMap, annotationErr := util.ParseMap(deployFlags.annotationOpts, "annotation")
 
 	if annotationErr != nil {
-		return statusCode, fmt.Errorf("error parsing annotations: %v", annotationErr)
+		return statusCode, nil, fmt.Errorf("error parsing annotations: %v", annotationErr)
 	}
 
 	deploySpec := &proxy.DeployFunctionSpec{
@@ -417,7 +658,7 @@ func deployImage(
 
 	statusCode = client.DeployFunction(ctx, deploySpec)
 
-	return statusCode, nil
+	return statusCode, deploySpec, nil
 }
 
 func readFiles(files []string) (map[string]string, error) {
@@ -4,16 +4,26 @@
 package commands
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
+	"net/http/httptest"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 
+	"github.com/openfaas/faas-cli/config"
+	"github.com/openfaas/faas-cli/proxy"
 	"github.com/openfaas/faas-cli/test"
+	types "github.com/openfaas/faas-provider/types"
 )
 
 func Test_deploy(t *testing.T) {
+	t.Setenv(config.ConfigLocationEnv, t.TempDir())
+
 	s := test.MockHttpServer(t, []test.Request{
 		{
 			Method:             http.MethodPut,
@@ -42,6 +52,41 @@ func Test_deploy(t *testing.T) {
 	}
 }
 
+func Test_deploy_DryRunWithoutStackFile(t *testing.T) {
+	t.Setenv(config.ConfigLocationEnv, t.TempDir())
+
+	var writes int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/system/functions" && (r.Method == http.MethodPost || r.Method == http.MethodPut) {
+			atomic.AddInt32(&writes, 1)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer s.Close()
+
+	resetForTest()
+	defer func() { deployFlags.dryRun = false }()
+
+	var err error
+	test.CaptureStdout(func() {
+		faasCmd.SetArgs([]string{
+			"deploy",
+			"--gateway=" + s.URL,
+			"--image=golang",
+			"--name=test-function",
+			"--dry-run",
+		})
+		err = faasCmd.Execute()
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "--dry-run") {
+		t.Fatalf("want an error for --dry-run without a stack file, got: %v", err)
+	}
+	if n := atomic.LoadInt32(&writes); n != 0 {
+		t.Fatalf("want no deployment to reach the gateway, got: %d", n)
+	}
+}
+
 func Test_deployFailed(t *testing.T) {
 
 	var failedDeploy = make(map[string]int)
@@ -90,3 +135,56 @@ func Test_badStatusCOde(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func Test_deployStack_Parallel(t *testing.T) {
+	var mu sync.Mutex
+	deployed := map[string]bool{}
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := types.FunctionDeployment{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("unable to decode body: %s", err)
+		}
+
+		mu.Lock()
+		deployed[req.Service] = true
+		mu.Unlock()
+
+		if req.Service == "fn-fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer s.Close()
+
+	cliAuth, err := proxy.NewCLIAuth("", s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proxyClient, err := proxy.NewClient(cliAuth, s.URL, nil, &commandTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	specs := []*proxy.DeployFunctionSpec{
+		{FunctionName: "fn-a", Image: "fn-a:latest"},
+		{FunctionName: "fn-b", Image: "fn-b:latest"},
+		{FunctionName: "fn-c", Image: "fn-c:latest"},
+		{FunctionName: "fn-fail", Image: "fn-fail:latest"},
+	}
+
+	var failed map[string]int
+	test.CaptureStdout(func() {
+		failed, _ = deployStack(context.Background(), proxyClient, s.URL, specs, 3, rolloutOptions{}, nil)
+	})
+
+	if len(deployed) != len(specs) {
+		t.Fatalf("want %d functions deployed, got %d", len(specs), len(deployed))
+	}
+
+	if len(failed) != 1 || failed["fn-fail"] != http.StatusInternalServerError {
+		t.Fatalf("want only fn-fail to fail with 500, got %v", failed)
+	}
+}
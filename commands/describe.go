@@ -14,6 +14,7 @@ import (
 	"strings"
 	"text/tabwriter"
 
+	"github.com/openfaas/faas-cli/output"
 	"github.com/openfaas/faas-cli/proxy"
 	"github.com/openfaas/faas-cli/schema"
 	"github.com/openfaas/faas-provider/types"
@@ -22,6 +23,8 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var describeFormat string
+
 func init() {
 	describeCmd.Flags().StringVar(&functionName, "name", "", "Name of the function")
 	describeCmd.Flags().StringVarP(&gateway, "gateway", "g", defaultGateway, "Gateway URL starting with http(s)://")
@@ -30,6 +33,8 @@ func init() {
 	describeCmd.Flags().StringVarP(&token, "token", "k", "", "Pass a JWT token to use instead of basic auth")
 	describeCmd.Flags().StringVarP(&functionNamespace, "namespace", "n", "", "Namespace of the function")
 	describeCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output")
+	describeCmd.Flags().StringVar(&describeFormat, "format", "", "Print the function with a Go template, i.e. '{{.Status}} {{.AvailableReplicas}}/{{.Replicas}}'")
+	describeCmd.Flags().StringVarP(&outputFormat, "output", "o", "", output.FlagUsage)
 
 	faasCmd.AddCommand(describeCmd)
 }
@@ -40,7 +45,9 @@ var describeCmd = &cobra.Command{
 	Long:  `Display details of an OpenFaaS function`,
 	Example: `faas-cli describe figlet
 faas-cli describe env --gateway http://127.0.0.1:8080
-faas-cli describe echo -g http://127.0.0.1.8080`,
+faas-cli describe echo -g http://127.0.0.1.8080
+faas-cli describe env --format '{{.Image}} {{json .EnvVars}}'
+faas-cli describe env -o yaml`,
 	PreRunE: preRunDescribe,
 	RunE:    runDescribe,
 }
@@ -57,6 +64,11 @@ func runDescribe(cmd *cobra.Command, args []string) error {
 	var services stack.Services
 	functionName = args[0]
 
+	printer, err := newOutputPrinter(outputFormat, describeFormat)
+	if err != nil {
+		return err
+	}
+
 	if len(yamlFile) > 0 {
 		parsedServices, err := stack.ParseYAMLFile(yamlFile, regex, filter, envsubst)
 		if err != nil {
@@ -115,9 +127,13 @@ func runDescribe(cmd *cobra.Command, args []string) error {
 		AsyncURL:        asyncURL,
 	}
 
-	printFunctionDescription(cmd.OutOrStdout(), funcDesc, verbose)
-
-	return nil
+	return printer.Print(cmd.OutOrStdout(), output.Result{
+		Object: funcDesc,
+		Names:  []string{funcDesc.Name},
+		Table: func(w io.Writer, wide bool) {
+			printFunctionDescription(w, funcDesc, verbose || wide)
+		},
+	})
 }
 
 func getFunctionURLs(gateway string, functionName string, functionNamespace string) (string, string) {
@@ -2,10 +2,13 @@ package commands
 
 import (
 	"bytes"
+	"encoding/json"
+	"net/http"
 	"regexp"
 	"testing"
 
 	"github.com/openfaas/faas-cli/schema"
+	"github.com/openfaas/faas-cli/test"
 	"github.com/openfaas/faas-provider/types"
 )
 
@@ -201,3 +204,91 @@ func TestGenerateMapOrder(t *testing.T) {
 		}
 	}
 }
+
+func Test_describe_Format(t *testing.T) {
+	s := test.MockHttpServer(t, []test.Request{
+		{
+			Method:             http.MethodGet,
+			Uri:                "/system/function/figlet?usage=1",
+			ResponseStatusCode: http.StatusOK,
+			ResponseBody:       types.FunctionStatus{Name: "figlet", Image: "openfaas/figlet:latest", Replicas: 2, AvailableReplicas: 1},
+		},
+		{
+			Method:             http.MethodGet,
+			Uri:                "/system/functions",
+			ResponseStatusCode: http.StatusOK,
+			ResponseBody:       []types.FunctionStatus{{Name: "figlet", InvocationCount: 7}},
+		},
+	})
+	defer s.Close()
+
+	resetForTest()
+	defer func() { describeFormat = "" }()
+
+	stdOut := test.CaptureStdout(func() {
+		faasCmd.SetArgs([]string{
+			"describe",
+			"--gateway=" + s.URL,
+			"--format={{.Status}} {{.AvailableReplicas}}/{{.Replicas}} {{.InvocationCount}} {{.URL}}",
+			"figlet",
+		})
+		if err := faasCmd.Execute(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	want := "Ready 1/2 7 " + s.URL + "/function/figlet\n"
+	if stdOut != want {
+		t.Fatalf("want %q, got: %q", want, stdOut)
+	}
+}
+
+func Test_describe_OutputJSON(t *testing.T) {
+	s := test.MockHttpServer(t, []test.Request{
+		{
+			Method:             http.MethodGet,
+			Uri:                "/system/function/figlet?usage=1",
+			ResponseStatusCode: http.StatusOK,
+			ResponseBody:       types.FunctionStatus{Name: "figlet", Image: "openfaas/figlet:latest", AvailableReplicas: 1},
+		},
+		{
+			Method:             http.MethodGet,
+			Uri:                "/system/functions",
+			ResponseStatusCode: http.StatusOK,
+			ResponseBody:       []types.FunctionStatus{{Name: "figlet", InvocationCount: 7}},
+		},
+	})
+	defer s.Close()
+
+	resetForTest()
+	defer func() { outputFormat = "" }()
+
+	var err error
+	stdOut := test.CaptureStdout(func() {
+		faasCmd.SetArgs([]string{"describe", "--gateway=" + s.URL, "-o", "json", "figlet"})
+		err = faasCmd.Execute()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(stdOut), &got); err != nil {
+		t.Fatalf("want JSON, got: %q", stdOut)
+	}
+
+	want := map[string]interface{}{
+		"name":              "figlet",
+		"image":             "openfaas/figlet:latest",
+		"availableReplicas": float64(1),
+		"status":            "Ready",
+		"invocationCount":   float64(7),
+		"url":               s.URL + "/function/figlet",
+		"asyncUrl":          s.URL + "/async-function/figlet",
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("want %s to be %v, got: %v", key, value, got[key])
+		}
+	}
+}
@@ -0,0 +1,377 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/morikuni/aec"
+	"github.com/openfaas/faas-cli/proxy"
+	"github.com/openfaas/faas-provider/types"
+	"github.com/openfaas/go-sdk/stack"
+	"github.com/spf13/cobra"
+)
+
+const (
+	diffAdded   = "+"
+	diffRemoved = "-"
+	diffChanged = "~"
+)
+
+// ignoredDiffLabels are set by the provider and would otherwise always
+// show up as drift.
+var ignoredDiffLabels = map[string]bool{
+	"faas_function": true,
+	"uid":           true,
+}
+
+// ignoredDiffAnnotations are set by the provider and would otherwise always
+// show up as drift.
+var ignoredDiffAnnotations = map[string]bool{
+	"prometheus.io.scrape": true,
+}
+
+// fieldDiff is a single difference between the stack file and the gateway
+type fieldDiff struct {
+	Kind  string
+	Field string
+	Old   string
+	New   string
+}
+
+// functionDiff holds the differences found for a single function
+type functionDiff struct {
+	Name      string
+	Namespace string
+	Kind      string
+	Fields    []fieldDiff
+}
+
+func init() {
+	diffCmd.Flags().StringVarP(&gateway, "gateway", "g", defaultGateway, "Gateway URL starting with http(s)://")
+	diffCmd.Flags().StringVarP(&functionNamespace, "namespace", "n", "", "Namespace of the function")
+	diffCmd.Flags().BoolVar(&tlsInsecure, "tls-no-verify", false, "Disable TLS validation")
+	diffCmd.Flags().BoolVar(&envsubst, "envsubst", true, "Substitute environment variables in stack.yaml file")
+	diffCmd.Flags().StringVarP(&token, "token", "k", "", "Pass a JWT token to use instead of basic auth")
+	diffCmd.Flags().Var(&tagFormat, "tag", "Override latest tag on function Docker image, accepts 'latest', 'sha', 'branch', or 'describe'")
+
+	diffCmd.Flags().StringArrayVarP(&deployFlags.envvarOpts, "env", "e", []string{}, "Set one or more environment variables (ENVVAR=VALUE)")
+	diffCmd.Flags().StringArrayVarP(&deployFlags.labelOpts, "label", "l", []string{}, "Set one or more label (LABEL=VALUE)")
+	diffCmd.Flags().StringArrayVarP(&deployFlags.annotationOpts, "annotation", "", []string{}, "Set one or more annotation (ANNOTATION=VALUE)")
+	diffCmd.Flags().StringArrayVar(&deployFlags.constraints, "constraint", []string{}, "Apply a constraint to the function")
+	diffCmd.Flags().StringArrayVar(&deployFlags.secrets, "secret", []string{}, "Give the function access to a secure secret")
+	diffCmd.Flags().StringVar(&deployFlags.stackName, "stack-name", "", "Name of the stack, set as the \""+stackLabel+"\" label on each function, defaults to the path of the stack file within its repository")
+
+	faasCmd.AddCommand(diffCmd)
+}
+
+// diffCmd compares the functions in a stack file with those on the gateway
+var diffCmd = &cobra.Command{
+	Use:   `diff -f YAML_FILE [--gateway GATEWAY_URL] [--regex "REGEX"] [--filter "WILDCARD"]`,
+	Short: "Show the differences between a stack file and the deployed functions",
+	Long: `Compares the functions in the stack file with the functions running on the
+gateway, and prints each field that a deploy would change.
+
+Functions which are in the stack file but not deployed are shown with a "+",
+and functions which were deployed from the same stack, but have since been
+removed from the stack file, are shown with a "-". Removed functions are found
+by the stack label, see --stack-name, and are not shown with --filter or --regex.
+
+The command exits with status 2 when any drift is found, and with status 1
+when the diff can't be made.`,
+	Example: `  faas-cli diff -f stack.yaml
+  faas-cli diff -f stack.yaml --filter "*gif*"
+  faas-cli diff -f stack.yaml --tag sha
+  faas-cli diff -f stack.yaml --env MODE=production`,
+	RunE: runDiff,
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	if len(yamlFile) == 0 {
+		return fmt.Errorf("give a stack file with --yaml/-f")
+	}
+
+	services, err := stack.ParseYAMLFile(yamlFile, regex, filter, envsubst)
+	if err != nil {
+		return err
+	}
+
+	gatewayAddress := getGatewayURL(gateway, defaultGateway, services.Provider.GatewayURL, os.Getenv(openFaaSURLEnvironment))
+
+	diffFlags := deployFlags
+	diffFlags.stackName = getStackName(diffFlags.stackName, yamlFile)
+
+	specs, err := buildStackSpecs(*services, diffFlags, tagFormat)
+	if err != nil {
+		return err
+	}
+
+	cliAuth, err := proxy.NewCLIAuth(token, gatewayAddress)
+	if err != nil {
+		return err
+	}
+
+	transport := GetDefaultCLITransport(tlsInsecure, &commandTimeout)
+	proxyClient, err := proxy.NewClient(cliAuth, gatewayAddress, transport, &commandTimeout)
+	if err != nil {
+		return err
+	}
+
+	diffs, err := diffStack(context.Background(), proxyClient, specs, removedStackName(diffFlags.stackName))
+	if err != nil {
+		return err
+	}
+
+	printFunctionDiffs(cmd.OutOrStdout(), diffs)
+
+	if len(diffs) > 0 {
+		return &driftError{count: len(diffs)}
+	}
+
+	return nil
+}
+
+// driftExitCode is the exit status when drift is found, so that CI can tell
+// drift apart from a diff which failed, which exits with 1.
+const driftExitCode = 2
+
+// driftError is returned when the deployed functions differ from the stack
+// file.
+type driftError struct {
+	count int
+}
+
+func (e *driftError) Error() string {
+	return fmt.Sprintf("drift found in %d function(s)", e.count)
+}
+
+// removedStackName returns the stack name used to find functions removed from
+// the stack file. None are reported when --filter or --regex is given, as the
+// functions left out by them have not been removed.
+func removedStackName(stackName string) string {
+	if len(regex) > 0 || len(filter) > 0 {
+		return ""
+	}
+	return stackName
+}
+
+// diffStack fetches the functions in each namespace used by the specs and
+// returns the differences, sorted by namespace and then by name. Deployed
+// functions which are not in the specs are only reported when they carry the
+// stack label for stackName, the same check used by --prune, and never when
+// stackName is empty.
+func diffStack(ctx context.Context, proxyClient *proxy.Client, specs []*proxy.DeployFunctionSpec, stackName string) ([]functionDiff, error) {
+	inStack := map[string]map[string]*proxy.DeployFunctionSpec{}
+	for _, spec := range specs {
+		if _, ok := inStack[spec.Namespace]; !ok {
+			inStack[spec.Namespace] = map[string]*proxy.DeployFunctionSpec{}
+		}
+		inStack[spec.Namespace][spec.FunctionName] = spec
+	}
+
+	diffs := []functionDiff{}
+	for namespace, stackFunctions := range inStack {
+		deployed, err := proxyClient.ListFunctions(ctx, namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		deployedNames := map[string]bool{}
+		for _, fn := range deployed {
+			deployedNames[fn.Name] = true
+
+			if len(stackName) == 0 || fn.Labels == nil || (*fn.Labels)[stackLabel] != stackName {
+				continue
+			}
+
+			if _, ok := stackFunctions[fn.Name]; !ok {
+				diffs = append(diffs, functionDiff{Name: fn.Name, Namespace: namespace, Kind: diffRemoved})
+			}
+		}
+
+		for name, spec := range stackFunctions {
+			if !deployedNames[name] {
+				diffs = append(diffs, functionDiff{Name: name, Namespace: namespace, Kind: diffAdded})
+				continue
+			}
+
+			status, err := proxyClient.GetFunctionInfo(ctx, name, namespace)
+			if err != nil {
+				return nil, err
+			}
+
+			if fields := diffFunction(spec, status); len(fields) > 0 {
+				diffs = append(diffs, functionDiff{Name: name, Namespace: namespace, Kind: diffChanged, Fields: fields})
+			}
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].Namespace != diffs[j].Namespace {
+			return diffs[i].Namespace < diffs[j].Namespace
+		}
+		return diffs[i].Name < diffs[j].Name
+	})
+
+	return diffs, nil
+}
+
+// diffFunction compares the spec that would be deployed with the status
+// reported by the gateway.
+func diffFunction(spec *proxy.DeployFunctionSpec, status types.FunctionStatus) []fieldDiff {
+	fields := []fieldDiff{}
+
+	fields = append(fields, diffValue("image", status.Image, spec.Image)...)
+	if len(spec.FProcess) > 0 {
+		fields = append(fields, diffValue("fprocess", status.EnvProcess, spec.FProcess)...)
+	}
+
+	deployedEnv := map[string]string{}
+	for k, v := range status.EnvVars {
+		if k != "fprocess" {
+			deployedEnv[k] = v
+		}
+	}
+	fields = append(fields, diffMap("env", deployedEnv, spec.EnvVars, nil)...)
+
+	var deployedLabels, deployedAnnotations map[string]string
+	if status.Labels != nil {
+		deployedLabels = *status.Labels
+	}
+	if status.Annotations != nil {
+		deployedAnnotations = *status.Annotations
+	}
+	fields = append(fields, diffMap("labels", deployedLabels, spec.Labels, ignoredDiffLabels)...)
+	fields = append(fields, diffMap("annotations", deployedAnnotations, spec.Annotations, ignoredDiffAnnotations)...)
+
+	fields = append(fields, diffList("secrets", status.Secrets, spec.Secrets)...)
+	fields = append(fields, diffList("constraints", status.Constraints, spec.Constraints)...)
+
+	fields = append(fields, diffResources("limits", status.Limits, spec.FunctionResourceRequest.Limits)...)
+	fields = append(fields, diffResources("requests", status.Requests, spec.FunctionResourceRequest.Requests)...)
+
+	fields = append(fields, diffValue("readOnlyRootFilesystem",
+		strconv.FormatBool(status.ReadOnlyRootFilesystem),
+		strconv.FormatBool(spec.ReadOnlyRootFilesystem))...)
+
+	return fields
+}
+
+func diffValue(field, deployed, desired string) []fieldDiff {
+	switch {
+	case deployed == desired:
+		return nil
+	case len(deployed) == 0:
+		return []fieldDiff{{Kind: diffAdded, Field: field, New: desired}}
+	case len(desired) == 0:
+		return []fieldDiff{{Kind: diffRemoved, Field: field, Old: deployed}}
+	}
+	return []fieldDiff{{Kind: diffChanged, Field: field, Old: deployed, New: desired}}
+}
+
+func diffMap(field string, deployed, desired map[string]string, ignored map[string]bool) []fieldDiff {
+	keys := map[string]bool{}
+	for k := range deployed {
+		keys[k] = true
+	}
+	for k := range desired {
+		keys[k] = true
+	}
+
+	fields := []fieldDiff{}
+	for _, k := range sortedKeys(keys) {
+		if ignored[k] {
+			continue
+		}
+		fields = append(fields, diffValue(field+"."+k, deployed[k], desired[k])...)
+	}
+	return fields
+}
+
+func diffList(field string, deployed, desired []string) []fieldDiff {
+	deployedSet := map[string]bool{}
+	for _, v := range deployed {
+		deployedSet[v] = true
+	}
+	desiredSet := map[string]bool{}
+	for _, v := range desired {
+		desiredSet[v] = true
+	}
+
+	fields := []fieldDiff{}
+	for _, v := range sortedKeys(deployedSet) {
+		if !desiredSet[v] {
+			fields = append(fields, fieldDiff{Kind: diffRemoved, Field: field, Old: v})
+		}
+	}
+	for _, v := range sortedKeys(desiredSet) {
+		if !deployedSet[v] {
+			fields = append(fields, fieldDiff{Kind: diffAdded, Field: field, New: v})
+		}
+	}
+	return fields
+}
+
+func diffResources(field string, deployed *types.FunctionResources, desired *stack.FunctionResources) []fieldDiff {
+	var deployedCPU, deployedMemory, desiredCPU, desiredMemory string
+	if deployed != nil {
+		deployedCPU, deployedMemory = deployed.CPU, deployed.Memory
+	}
+	if desired != nil {
+		desiredCPU, desiredMemory = desired.CPU, desired.Memory
+	}
+
+	fields := diffValue(field+".cpu", deployedCPU, desiredCPU)
+	return append(fields, diffValue(field+".memory", deployedMemory, desiredMemory)...)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func printFunctionDiffs(w io.Writer, diffs []functionDiff) {
+	if len(diffs) == 0 {
+		fmt.Fprintln(w, "No differences found.")
+		return
+	}
+
+	for _, d := range diffs {
+		name := d.Name
+		if len(d.Namespace) > 0 {
+			name = d.Name + "." + d.Namespace
+		}
+
+		switch d.Kind {
+		case diffAdded:
+			fmt.Fprintln(w, aec.GreenF.Apply(fmt.Sprintf("%s %s (not deployed)", diffAdded, name)))
+		case diffRemoved:
+			fmt.Fprintln(w, aec.RedF.Apply(fmt.Sprintf("%s %s (not in stack file)", diffRemoved, name)))
+		default:
+			fmt.Fprintf(w, "%s %s\n", diffChanged, name)
+			for _, f := range d.Fields {
+				fmt.Fprintf(w, "    %s\n", formatFieldDiff(f))
+			}
+		}
+	}
+}
+
+func formatFieldDiff(f fieldDiff) string {
+	switch f.Kind {
+	case diffAdded:
+		return aec.GreenF.Apply(fmt.Sprintf("%s %s: %s", f.Kind, f.Field, f.New))
+	case diffRemoved:
+		return aec.RedF.Apply(fmt.Sprintf("%s %s: %s", f.Kind, f.Field, f.Old))
+	}
+	return aec.YellowF.Apply(fmt.Sprintf("%s %s: %s => %s", f.Kind, f.Field, f.Old, f.New))
+}
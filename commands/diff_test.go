@@ -0,0 +1,219 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openfaas/faas-cli/proxy"
+	"github.com/openfaas/faas-cli/test"
+	"github.com/openfaas/faas-provider/types"
+	"github.com/openfaas/go-sdk/stack"
+)
+
+func Test_diffFunction_NoChanges(t *testing.T) {
+	spec := &proxy.DeployFunctionSpec{
+		FunctionName: "figlet",
+		Image:        "ghcr.io/openfaas/figlet:latest",
+		EnvVars:      map[string]string{"mode": "fast"},
+		Labels:       map[string]string{"team": "a"},
+		Secrets:      []string{"s1", "s2"},
+	}
+
+	status := types.FunctionStatus{
+		Name:    "figlet",
+		Image:   "ghcr.io/openfaas/figlet:latest",
+		EnvVars: map[string]string{"mode": "fast", "fprocess": "figlet"},
+		Labels:  &map[string]string{"team": "a", "faas_function": "figlet"},
+		Secrets: []string{"s2", "s1"},
+	}
+
+	if fields := diffFunction(spec, status); len(fields) != 0 {
+		t.Fatalf("want no differences, got %v", fields)
+	}
+}
+
+func Test_diffFunction_Changes(t *testing.T) {
+	spec := &proxy.DeployFunctionSpec{
+		FunctionName: "figlet",
+		Image:        "ghcr.io/openfaas/figlet:0.2.0",
+		EnvVars:      map[string]string{"mode": "slow", "debug": "true"},
+		Secrets:      []string{"s1"},
+		FunctionResourceRequest: proxy.FunctionResourceRequest{
+			Limits: &stack.FunctionResources{Memory: "256Mi"},
+		},
+	}
+
+	status := types.FunctionStatus{
+		Name:    "figlet",
+		Image:   "ghcr.io/openfaas/figlet:0.1.0",
+		EnvVars: map[string]string{"mode": "fast"},
+		Secrets: []string{"s2"},
+		Limits:  &types.FunctionResources{Memory: "128Mi"},
+	}
+
+	got := map[string]fieldDiff{}
+	for _, f := range diffFunction(spec, status) {
+		got[f.Kind+f.Field+f.Old+f.New] = f
+	}
+
+	want := []fieldDiff{
+		{Kind: diffChanged, Field: "image", Old: "ghcr.io/openfaas/figlet:0.1.0", New: "ghcr.io/openfaas/figlet:0.2.0"},
+		{Kind: diffChanged, Field: "env.mode", Old: "fast", New: "slow"},
+		{Kind: diffAdded, Field: "env.debug", New: "true"},
+		{Kind: diffRemoved, Field: "secrets", Old: "s2"},
+		{Kind: diffAdded, Field: "secrets", New: "s1"},
+		{Kind: diffChanged, Field: "limits.memory", Old: "128Mi", New: "256Mi"},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("want %d differences, got %d: %v", len(want), len(got), got)
+	}
+
+	for _, w := range want {
+		if _, ok := got[w.Kind+w.Field+w.Old+w.New]; !ok {
+			t.Errorf("want difference %v, not found in %v", w, got)
+		}
+	}
+}
+
+func Test_diffStack_MissingFunctions(t *testing.T) {
+	s := test.MockHttpServer(t, []test.Request{
+		{
+			Method:             http.MethodGet,
+			Uri:                "/system/functions",
+			ResponseStatusCode: http.StatusOK,
+			ResponseBody: []types.FunctionStatus{
+				{Name: "figlet", Image: "figlet:latest"},
+				{Name: "old-fn", Image: "old-fn:latest", Labels: &map[string]string{stackLabel: "shop"}},
+				{Name: "other-stack", Image: "other-stack:latest", Labels: &map[string]string{stackLabel: "billing"}},
+				{Name: "unmanaged", Image: "unmanaged:latest"},
+			},
+		},
+		{
+			Method:             http.MethodGet,
+			Uri:                "/system/function/figlet?usage=1",
+			ResponseStatusCode: http.StatusOK,
+			ResponseBody:       types.FunctionStatus{Name: "figlet", Image: "figlet:latest"},
+		},
+	})
+	defer s.Close()
+
+	cliAuth, err := proxy.NewCLIAuth("", s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proxyClient, err := proxy.NewClient(cliAuth, s.URL, nil, &commandTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	specs := []*proxy.DeployFunctionSpec{
+		{FunctionName: "figlet", Image: "figlet:latest"},
+		{FunctionName: "new-fn", Image: "new-fn:latest"},
+	}
+
+	diffs, err := diffStack(context.Background(), proxyClient, specs, "shop")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(diffs) != 2 {
+		t.Fatalf("want 2 differences, got %d: %v", len(diffs), diffs)
+	}
+
+	if diffs[0].Name != "new-fn" || diffs[0].Kind != diffAdded {
+		t.Errorf("want new-fn to be added, got %v", diffs[0])
+	}
+
+	if diffs[1].Name != "old-fn" || diffs[1].Kind != diffRemoved {
+		t.Errorf("want old-fn to be removed, got %v", diffs[1])
+	}
+
+	buf := bytes.Buffer{}
+	printFunctionDiffs(&buf, diffs)
+	if !strings.Contains(buf.String(), "new-fn (not deployed)") {
+		t.Errorf("want new-fn in output, got: %s", buf.String())
+	}
+}
+
+func Test_diffStack_NoRemovalsWithoutStackName(t *testing.T) {
+	s := test.MockHttpServer(t, []test.Request{
+		{
+			Method:             http.MethodGet,
+			Uri:                "/system/functions",
+			ResponseStatusCode: http.StatusOK,
+			ResponseBody: []types.FunctionStatus{
+				{Name: "old-fn", Image: "old-fn:latest", Labels: &map[string]string{stackLabel: "shop"}},
+			},
+		},
+	})
+	defer s.Close()
+
+	cliAuth, err := proxy.NewCLIAuth("", s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proxyClient, err := proxy.NewClient(cliAuth, s.URL, nil, &commandTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filter = "figlet"
+	defer func() { filter = "" }()
+
+	specs := []*proxy.DeployFunctionSpec{{FunctionName: "new-fn", Image: "new-fn:latest"}}
+	diffs, err := diffStack(context.Background(), proxyClient, specs, removedStackName("shop"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(diffs) != 1 || diffs[0].Kind != diffAdded {
+		t.Fatalf("want only new-fn to be added when the stack is filtered, got: %v", diffs)
+	}
+}
+
+func Test_diff_DriftExitCode(t *testing.T) {
+	s := test.MockHttpServer(t, []test.Request{
+		{
+			Method:             http.MethodGet,
+			Uri:                "/system/functions",
+			ResponseStatusCode: http.StatusOK,
+			ResponseBody:       []types.FunctionStatus{},
+		},
+	})
+	defer s.Close()
+
+	stackFile := filepath.Join(t.TempDir(), "stack.yaml")
+	stackYAML := "version: 1.0\nprovider:\n  name: openfaas\nfunctions:\n  figlet:\n    image: figlet:latest\n"
+	if err := os.WriteFile(stackFile, []byte(stackYAML), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	resetForTest()
+	defer resetForTest()
+
+	var err error
+	test.CaptureStdout(func() {
+		faasCmd.SetArgs([]string{"diff", "-f", stackFile, "--gateway=" + s.URL})
+		err = faasCmd.Execute()
+	})
+
+	var drift *driftError
+	if !errors.As(err, &drift) {
+		t.Fatalf("want a drift error for a function which is not deployed, got: %v", err)
+	}
+	if drift.count != 1 {
+		t.Fatalf("want drift in 1 function, got: %d", drift.count)
+	}
+}
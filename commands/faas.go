@@ -11,10 +11,14 @@ import (
 	"os/exec"
 	"path"
 	"runtime"
+	"strconv"
 	"strings"
 	"syscall"
 
 	"github.com/moby/term"
+	"github.com/openfaas/faas-cli/config"
+	"github.com/openfaas/faas-cli/har"
+	"github.com/openfaas/faas-cli/proxy"
 	"github.com/openfaas/faas-cli/version"
 	"github.com/openfaas/go-sdk/stack"
 	"github.com/spf13/cobra"
@@ -31,9 +35,12 @@ const (
 
 // Flags that are to be added to all commands.
 var (
-	yamlFile string
-	regex    string
-	filter   string
+	yamlFile    string
+	regex       string
+	filter      string
+	contextName string
+	retries     int
+	recordHAR   string
 )
 
 // Flags that are to be added to subset of commands.
@@ -48,6 +55,7 @@ var (
 	imagePrefix  string
 	language     string
 	tlsInsecure  bool
+	outputFormat string
 )
 
 // Services parsed from stack file
@@ -62,6 +70,7 @@ func resetForTest() {
 	yamlFile = ""
 	regex = ""
 	filter = ""
+	contextName = ""
 	version.Version = ""
 	shortVersion = false
 	appendFile = ""
@@ -74,6 +83,9 @@ func init() {
 	faasCmd.PersistentFlags().StringVarP(&yamlFile, "yaml", "f", "", "Path to YAML file describing function(s)")
 	faasCmd.PersistentFlags().StringVarP(&regex, "regex", "", "", "Regex to match with function names in YAML file")
 	faasCmd.PersistentFlags().StringVarP(&filter, "filter", "", "", "Wildcard to match with function names in YAML file")
+	faasCmd.PersistentFlags().StringVar(&contextName, "context", "", "Name of a context from the config file to use instead of the current context")
+	faasCmd.PersistentFlags().StringVar(&recordHAR, "record-har", "", "Write every request made to the gateway, and its response, to a HAR file for a bug report")
+	faasCmd.PersistentFlags().IntVar(&retries, "retries", proxy.DefaultRetryPolicy.Retries, "Number of times to retry a request when the gateway is unavailable, setting it also retries deployments (env: "+retriesEnvironment+")")
 
 	// Set Bash completion options
 	validYAMLFilenames := []string{"yaml", "yml"}
@@ -135,9 +147,17 @@ func Execute(customArgs []string) {
 		}
 	}
 
-	if err := faasCmd.Execute(); err != nil {
+	err = faasCmd.Execute()
+	closeHARRecorder()
+
+	if err != nil {
 		e := err.Error()
 		fmt.Println(strings.ToUpper(e[:1]) + e[1:])
+
+		var drift *driftError
+		if errors.As(err, &drift) {
+			os.Exit(driftExitCode)
+		}
 		os.Exit(1)
 	}
 }
@@ -158,7 +178,88 @@ var faasCmd = &cobra.Command{
 	Short: "Manage your OpenFaaS functions from the command line",
 	Long: `
 Manage your OpenFaaS functions from the command line`,
-	Run: runFaas,
+	PersistentPreRunE: preRunFaas,
+	Run:               runFaas,
+}
+
+func preRunFaas(cmd *cobra.Command, args []string) error {
+	gatewayFlagSet = cmd.Flags().Changed("gateway")
+
+	if err := applyContext(cmd, args); err != nil {
+		return err
+	}
+
+	if len(recordHAR) > 0 {
+		recorder, err := har.NewRecorder(recordHAR, version.BuildVersion())
+		if err != nil {
+			return fmt.Errorf("unable to write HAR file: %w", err)
+		}
+		harRecorder = recorder
+	}
+
+	// Token refreshes carry refresh tokens, so they are not recorded
+	proxy.DefaultAuthTransport = newCLITransport(tlsInsecure, &commandTimeout)
+
+	return applyRetries(cmd)
+}
+
+// closeHARRecorder writes the entries recorded since the HAR file was last
+// saved, before the CLI exits.
+func closeHARRecorder() {
+	if harRecorder == nil {
+		return
+	}
+
+	if err := harRecorder.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to write HAR file: %s\n", err)
+	}
+}
+
+// applyContext checks that the context given with --context exists, and
+// applies the TLS setting of the active context when --tls-no-verify was not
+// given.
+func applyContext(cmd *cobra.Command, args []string) error {
+	cliContext, ok, err := config.LookupContext(contextName)
+	if err != nil {
+		if len(contextName) > 0 {
+			return err
+		}
+		return nil
+	}
+
+	if ok && cliContext.TLSInsecure {
+		if f := cmd.Flags().Lookup("tls-no-verify"); f != nil && !f.Changed {
+			tlsInsecure = true
+		}
+	}
+
+	return nil
+}
+
+// applyRetries sets the retry policy used by gateway clients from --retries
+// or the OPENFAAS_RETRIES environment variable. Deployments are only retried
+// when one of them is set.
+func applyRetries(cmd *cobra.Command) error {
+	value := retries
+	if f := cmd.Flags().Lookup("retries"); f == nil || !f.Changed {
+		env, ok := os.LookupEnv(retriesEnvironment)
+		if !ok || len(env) == 0 {
+			return nil
+		}
+
+		var err error
+		if value, err = strconv.Atoi(env); err != nil {
+			return fmt.Errorf("invalid value for %s: %q", retriesEnvironment, env)
+		}
+	}
+
+	if value < 0 {
+		return fmt.Errorf("the number of retries must be 0 or greater")
+	}
+
+	proxy.DefaultRetryPolicy.Retries = value
+	proxy.DefaultRetryPolicy.Deploy = value > 0
+	return nil
 }
 
 // runFaas TODO
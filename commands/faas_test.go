@@ -4,6 +4,9 @@ import (
 	"io"
 	"os"
 	"testing"
+
+	"github.com/openfaas/faas-cli/proxy"
+	"github.com/spf13/cobra"
 )
 
 var mockStatParams string
@@ -58,3 +61,56 @@ func TestDoesNotLoadDefaultYAMLWhenMissing(t *testing.T) {
 		t.Fatalf("Expected yamlFile to be blank got %v\n", yamlFile)
 	}
 }
+
+func Test_applyRetries(t *testing.T) {
+	cases := []struct {
+		name        string
+		args        []string
+		env         string
+		wantRetries int
+		wantDeploy  bool
+		wantErr     bool
+	}{
+		{name: "defaults", wantRetries: proxy.DefaultRetryPolicy.Retries, wantDeploy: false},
+		{name: "flag", args: []string{"--retries=5"}, wantRetries: 5, wantDeploy: true},
+		{name: "env", env: "2", wantRetries: 2, wantDeploy: true},
+		{name: "flag overrides env", args: []string{"--retries=1"}, env: "4", wantRetries: 1, wantDeploy: true},
+		{name: "disabled", args: []string{"--retries=0"}, wantRetries: 0, wantDeploy: false},
+		{name: "invalid env", env: "many", wantErr: true},
+		{name: "negative", args: []string{"--retries=-1"}, wantErr: true},
+	}
+
+	defaultPolicy := proxy.DefaultRetryPolicy
+	defer func() { proxy.DefaultRetryPolicy = defaultPolicy }()
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			proxy.DefaultRetryPolicy = defaultPolicy
+			t.Setenv(retriesEnvironment, tc.env)
+
+			cmd := &cobra.Command{}
+			cmd.Flags().IntVar(&retries, "retries", defaultPolicy.Retries, "")
+			if err := cmd.ParseFlags(tc.args); err != nil {
+				t.Fatal(err)
+			}
+
+			err := applyRetries(cmd)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, got: %s", err)
+			}
+
+			if proxy.DefaultRetryPolicy.Retries != tc.wantRetries {
+				t.Fatalf("want %d retries, got: %d", tc.wantRetries, proxy.DefaultRetryPolicy.Retries)
+			}
+			if proxy.DefaultRetryPolicy.Deploy != tc.wantDeploy {
+				t.Fatalf("want deploy retries %v, got: %v", tc.wantDeploy, proxy.DefaultRetryPolicy.Deploy)
+			}
+		})
+	}
+}
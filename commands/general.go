@@ -2,6 +2,7 @@ package commands
 
 import (
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -10,14 +11,28 @@ import (
 	"time"
 
 	"github.com/openfaas/faas-cli/config"
+	"github.com/openfaas/faas-cli/har"
+	"github.com/openfaas/faas-cli/oidc"
+	"github.com/openfaas/faas-cli/output"
+	"github.com/openfaas/faas-cli/proxy"
 	"github.com/openfaas/go-sdk"
 )
 
 var (
 	commandTimeout = 60 * time.Second
+
+	// harRecorder records the traffic of gateway clients when --record-har
+	// is given
+	harRecorder *har.Recorder
 )
 
-func GetDefaultCLITransport(tlsInsecure bool, timeout *time.Duration) *http.Transport {
+func GetDefaultCLITransport(tlsInsecure bool, timeout *time.Duration) http.RoundTripper {
+	return withHARRecorder(newCLITransport(tlsInsecure, timeout))
+}
+
+// newCLITransport applies --tls-no-verify and the timeout to a transport,
+// without recording its requests with --record-har.
+func newCLITransport(tlsInsecure bool, timeout *time.Duration) http.RoundTripper {
 	if timeout != nil || tlsInsecure {
 		tr := &http.Transport{
 			Proxy:             http.ProxyFromEnvironment,
@@ -43,21 +58,59 @@ func GetDefaultCLITransport(tlsInsecure bool, timeout *time.Duration) *http.Tran
 	return nil
 }
 
+// withHARRecorder wraps the transport so that its requests are written to
+// the file given by --record-har.
+func withHARRecorder(transport http.RoundTripper) http.RoundTripper {
+	if harRecorder == nil {
+		return transport
+	}
+	return harRecorder.Wrap(transport)
+}
+
+// newOutputPrinter returns the printer for the --output flag, or for a
+// --format template, which is kept as a shorter form of -o go-template=.
+func newOutputPrinter(outputValue, format string) (*output.Printer, error) {
+	if len(format) > 0 {
+		if len(outputValue) > 0 {
+			return nil, fmt.Errorf("--format cannot be used with --output")
+		}
+		return output.NewTemplatePrinter(format)
+	}
+	return output.NewPrinter(outputValue)
+}
+
 func GetDefaultSDKClient() (*sdk.Client, error) {
+	return getDefaultSDKClient(commandTimeout)
+}
+
+// getDefaultSDKClient returns a client for the gateway given by the flags or
+// the stack file, with no timeout when timeout is zero.
+func getDefaultSDKClient(timeout time.Duration) (*sdk.Client, error) {
 	var yamlUrl string
 	if services != nil {
 		yamlUrl = services.Provider.GatewayURL
 	}
 
 	gatewayAddress := getGatewayURL(gateway, defaultGateway, yamlUrl, os.Getenv(openFaaSURLEnvironment))
+
+	return newSDKClientWithTimeout(gatewayAddress, timeout)
+}
+
+// newSDKClient returns a client for the given gateway, using the credentials
+// saved by "faas-cli login" or the --token flag.
+func newSDKClient(gatewayAddress string) (*sdk.Client, error) {
+	return newSDKClientWithTimeout(gatewayAddress, commandTimeout)
+}
+
+func newSDKClientWithTimeout(gatewayAddress string, timeout time.Duration) (*sdk.Client, error) {
 	gatewayURL, err := url.Parse(gatewayAddress)
 	if err != nil {
 		return nil, err
 	}
 
 	authConfig, err := config.LookupAuthConfig(gatewayURL.String())
-	if err != nil {
-		fmt.Printf("Failed to lookup auth config: %s\n", err)
+	if err != nil && !errors.Is(err, config.ErrConfigNotFound) {
+		fmt.Fprintf(os.Stderr, "Failed to lookup auth config: %s\n", err)
 	}
 
 	var clientAuth sdk.ClientAuth
@@ -75,12 +128,27 @@ func GetDefaultSDKClient() (*sdk.Client, error) {
 	}
 
 	if authConfig.Auth == config.Oauth2AuthType {
-		tokenAuth := &StaticTokenAuth{
-			token: authConfig.Token,
+		if oidc.IsMachineLogin(authConfig) {
+			tokenAuth, err := oidc.NewMachineAuth(authConfig)
+			if err != nil {
+				return nil, err
+			}
+
+			clientAuth = tokenAuth
+			functionTokenSource = tokenAuth
+		} else if oidc.IsRefreshable(authConfig) {
+			tokenAuth := oidc.NewTokenAuth(authConfig, proxy.DefaultAuthTransport)
+
+			clientAuth = tokenAuth
+			functionTokenSource = tokenAuth
+		} else {
+			tokenAuth := &StaticTokenAuth{
+				token: authConfig.Token,
+			}
+
+			clientAuth = tokenAuth
+			functionTokenSource = tokenAuth
 		}
-
-		clientAuth = tokenAuth
-		functionTokenSource = tokenAuth
 	}
 
 	// User specified token gets priority
@@ -94,7 +162,7 @@ func GetDefaultSDKClient() (*sdk.Client, error) {
 	}
 
 	httpClient := &http.Client{}
-	httpClient.Timeout = commandTimeout
+	httpClient.Timeout = timeout
 
 	transport := GetDefaultCLITransport(tlsInsecure, &commandTimeout)
 	if transport != nil {
@@ -104,6 +172,7 @@ func GetDefaultSDKClient() (*sdk.Client, error) {
 	return sdk.NewClientWithOpts(gatewayURL, httpClient,
 		sdk.WithAuthentication(clientAuth),
 		sdk.WithFunctionTokenSource(functionTokenSource),
+		sdk.WithFunctionTokenCache(sdk.NewMemoryTokenCache()),
 	), nil
 }
 
@@ -0,0 +1,91 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/openfaas/faas-cli/history"
+	"github.com/openfaas/go-sdk/stack"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	historyCmd.Flags().StringVarP(&gateway, "gateway", "g", defaultGateway, "Gateway URL starting with http(s)://")
+	historyCmd.Flags().StringVarP(&functionNamespace, "namespace", "n", "", "Namespace of the function")
+
+	faasCmd.AddCommand(historyCmd)
+}
+
+var historyCmd = &cobra.Command{
+	Use:   `history FUNCTION_NAME [--gateway GATEWAY_URL] [--namespace NAMESPACE]`,
+	Short: "List the recorded deployments of a function",
+	Long: `Lists the revisions of a function which were deployed from this machine
+with "faas-cli deploy". Revisions are kept in the faas-cli config directory.`,
+	Example: `  faas-cli history figlet
+  faas-cli history figlet --namespace staging-fn`,
+	RunE: runHistory,
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("please provide a name for the function")
+	}
+	functionName := args[0]
+
+	gatewayAddress, namespace := revisionKey(functionName)
+
+	revisions, err := history.List(gatewayAddress, namespace, functionName)
+	if err != nil {
+		return err
+	}
+
+	if len(revisions) == 0 {
+		fmt.Printf("No history found for %s.\n", functionName)
+		return nil
+	}
+
+	fmt.Printf("%s", renderHistory(revisions))
+
+	return nil
+}
+
+// revisionKey resolves the gateway and namespace of a function in the same way
+// as deploy does when it records a revision: from the flags, then the stack
+// file, OPENFAAS_URL and the active context.
+func revisionKey(functionName string) (string, string) {
+	var yamlGateway, stackNamespace string
+	if len(yamlFile) > 0 {
+		if services, err := stack.ParseYAMLFile(yamlFile, "", "", envsubst); err == nil {
+			yamlGateway = services.Provider.GatewayURL
+			stackNamespace = services.Functions[functionName].Namespace
+		}
+	}
+
+	gatewayAddress := getGatewayURL(gateway, defaultGateway, yamlGateway, os.Getenv(openFaaSURLEnvironment))
+	return gatewayAddress, getNamespace(functionNamespace, stackNamespace)
+}
+
+func renderHistory(revisions []history.Revision) string {
+	var b bytes.Buffer
+	w := tabwriter.NewWriter(&b, 0, 0, 1, ' ', 0)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "REVISION\tDEPLOYED\tGIT SHA\tIMAGE")
+
+	for _, r := range revisions {
+		sha := r.GitSHA
+		if len(sha) == 0 {
+			sha = "-"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", r.Revision, r.Timestamp.Local().Format(time.RFC3339), sha, r.Spec.Image)
+	}
+
+	fmt.Fprintln(w)
+	w.Flush()
+	return b.String()
+}
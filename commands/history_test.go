@@ -0,0 +1,78 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openfaas/faas-cli/config"
+	"github.com/openfaas/faas-cli/history"
+	"github.com/openfaas/faas-cli/proxy"
+	"github.com/openfaas/faas-cli/test"
+)
+
+func Test_history_NamespaceFromStackFile(t *testing.T) {
+	t.Setenv(config.ConfigLocationEnv, t.TempDir())
+
+	gatewayAddress := "http://gateway.test:8080"
+	spec := proxy.DeployFunctionSpec{FunctionName: "figlet", Image: "figlet:0.1.0", Namespace: "openfaas-fn"}
+	if _, err := history.Record(gatewayAddress, spec, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	stackFile := filepath.Join(t.TempDir(), "stack.yaml")
+	stackYAML := "version: 1.0\nprovider:\n  name: openfaas\nfunctions:\n  figlet:\n    image: figlet:0.1.0\n    namespace: openfaas-fn\n"
+	if err := os.WriteFile(stackFile, []byte(stackYAML), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	resetForTest()
+	defer resetForTest()
+
+	var err error
+	stdOut := test.CaptureStdout(func() {
+		faasCmd.SetArgs([]string{"history", "figlet", "-f", stackFile, "--gateway=" + gatewayAddress})
+		err = faasCmd.Execute()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(stdOut, "figlet:0.1.0") {
+		t.Fatalf("want the revision deployed to the stack file's namespace, got:\n%s", stdOut)
+	}
+}
+
+func Test_history_NamespaceFromContext(t *testing.T) {
+	t.Setenv(config.ConfigLocationEnv, t.TempDir())
+
+	defer func(fn func() (config.Context, bool)) { activeContext = fn }(activeContext)
+	activeContext = func() (config.Context, bool) {
+		return config.Context{Name: "staging", Namespace: "staging-fn"}, true
+	}
+
+	gatewayAddress := "http://gateway.test:8080"
+	spec := proxy.DeployFunctionSpec{FunctionName: "figlet", Image: "figlet:0.2.0", Namespace: getNamespace("", "")}
+	if _, err := history.Record(gatewayAddress, spec, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	resetForTest()
+
+	var err error
+	stdOut := test.CaptureStdout(func() {
+		faasCmd.SetArgs([]string{"history", "figlet", "--gateway=" + gatewayAddress})
+		err = faasCmd.Execute()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(stdOut, "figlet:0.2.0") {
+		t.Fatalf("want the revision deployed to the context's namespace, got:\n%s", stdOut)
+	}
+}
@@ -5,18 +5,22 @@ package commands
 
 import (
 	"bytes"
+	"context"
 	"crypto"
 	"encoding/hex"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptrace"
 	"net/url"
 	"os"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/alexellis/hmac/v2"
 	"github.com/openfaas/faas-cli/version"
+	"github.com/openfaas/go-sdk"
 	"github.com/openfaas/go-sdk/stack"
 	"github.com/spf13/cobra"
 )
@@ -49,18 +53,49 @@ func init() {
 	invokeCmd.Flags().BoolVarP(&invokeAsync, "async", "a", false, "Invoke the function asynchronously")
 	invokeCmd.Flags().StringVarP(&httpMethod, "method", "m", "POST", "pass HTTP request method")
 	invokeCmd.Flags().BoolVar(&tlsInsecure, "tls-no-verify", false, "Disable TLS validation")
+	invokeCmd.Flags().StringArrayVarP(&invokeForm, "form", "F", []string{}, "Send a multipart/form-data field instead of STDIN, as key=value, key=<path to read the value from a file, or key=@path;type=image/png to upload a file")
+	invokeCmd.Flags().StringArrayVar(&invokeDataURLEncode, "data-urlencode", []string{}, "Send an application/x-www-form-urlencoded field instead of STDIN, as key=value or key@path to read the value from a file")
 	invokeCmd.Flags().StringVar(&sigHeader, "sign", "", "name of HTTP request header to hold the signature")
 	invokeCmd.Flags().StringVar(&key, "key", "", "key to be used to sign the request (must be used with --sign)")
 
 	invokeCmd.Flags().BoolVar(&envsubst, "envsubst", true, "Substitute environment variables in stack.yaml file")
 
+	invokeCmd.Flags().IntVar(&invokeRequests, "requests", 0, "Load test the function with this number of requests")
+	invokeCmd.Flags().IntVar(&invokeConcurrency, "concurrency", 1, "Number of requests to make at the same time when load testing or running a batch")
+	invokeCmd.Flags().StringVar(&invokeRate, "rate", "", "Limit the rate of requests when load testing, such as 50/s or 600/m")
+	invokeCmd.Flags().DurationVar(&invokeDuration, "duration", 0, "Load test the function for this long, or until --requests have been made")
+	invokeCmd.Flags().StringVarP(&invokeOutput, "output", "o", "", "Output format: json for the status, headers and body of the response, table or json for a load test, jsonl to print each server-sent event of --stream as JSON")
+	invokeCmd.Flags().BoolVarP(&invokeVerbose, "verbose", "v", false, "Print the request and response headers, and the time taken by each phase of the request to STDERR")
+	invokeCmd.Flags().BoolVar(&invokeStream, "stream", false, "Stream the response as it arrives with no timeout, such as server-sent events")
+
+	invokeCmd.Flags().BoolVar(&invokeWaitCallback, "wait-callback", false, "Wait for the result of an --async invocation on a local callback listener")
+	invokeCmd.Flags().StringVar(&invokeCallbackAddr, "callback-addr", ":0", "Address to listen on for the callback of --wait-callback")
+	invokeCmd.Flags().StringVar(&invokeCallbackURL, "callback-url", "", "URL given to the gateway for the callback, such as a tunnel to --callback-addr for a remote gateway")
+	invokeCmd.Flags().DurationVar(&invokeCallbackTimeout, "callback-timeout", 5*time.Minute, "How long to wait for the callback of --wait-callback")
+
+	invokeCmd.Flags().StringVar(&invokeBatch, "batch", "", "Invoke the function once for each line of a JSONL or CSV file, use - for JSONL from STDIN")
+	invokeCmd.Flags().StringVar(&invokeBatchOutput, "batch-output", "", "Write the result of each line of a batch to this JSONL file instead of STDOUT")
+	invokeCmd.Flags().StringVar(&invokeBatchBodyDir, "batch-body-dir", "", "Write each response body of a batch to a file in this folder instead of the results")
+
 	faasCmd.AddCommand(invokeCmd)
 }
 
 var invokeCmd = &cobra.Command{
 	Use:   `invoke FUNCTION_NAME [--gateway GATEWAY_URL] [--content-type CONTENT_TYPE] [--query KEY=VALUE] [--header "KEY: VALUE"] [--method HTTP_METHOD]`,
 	Short: "Invoke an OpenFaaS function",
-	Long:  `Invokes an OpenFaaS function and reads from STDIN for the body of the request`,
+	Long: `Invokes an OpenFaaS function and reads from STDIN for the body of the request
+
+Use --requests or --duration to load test the function, the latency of each
+request and the X-Duration-Seconds reported by the watchdog are summarised.
+
+Use --batch to invoke the function once for each line of a file. Each line of a
+JSONL file is an object such as:
+
+  {"id": "a1", "method": "POST", "headers": {"X-Tenant": "t1"}, "query": {"v": "2"}, "body": "hello"}
+
+A CSV file has a header row naming the columns id, method, body and query, with
+query given as a=1&b=2, and header:NAME columns for headers. Fields which are
+not set fall back to the flags. A JSON result is written for each line.`,
 	Example: `  faas-cli invoke printer --gateway https://host:port <<< "Hello"
   faas-cli invoke echo --gateway https://host:port --content-type application/json
   faas-cli invoke env --query repo=faas-cli --query org=openfaas
@@ -68,7 +103,18 @@ var invokeCmd = &cobra.Command{
   faas-cli invoke resize-img --async -H "X-Callback-Url: http://gateway:8080/function/send2slack" < image.png
   faas-cli invoke env -H X-Ping-Url: http://request.bin/etc
   faas-cli invoke flask --method GET --namespace dev
-  faas-cli invoke env --sign X-GitHub-Event --key yoursecret`,
+  faas-cli invoke env --sign X-GitHub-Event --key yoursecret
+  faas-cli invoke figlet --async --wait-callback <<< "Hello"
+  faas-cli invoke figlet --async --wait-callback --callback-addr :8000 --callback-url https://tunnel.example.com
+  faas-cli invoke resize-img --form width=200 --form "image=@cat.png;type=image/png"
+  faas-cli invoke subscribe --data-urlencode email=alex@example.com --sign X-Signature --key yoursecret
+  faas-cli invoke env -v <<< "Hello"
+  faas-cli invoke env -o json | jq .status
+  faas-cli invoke chat --stream <<< "Tell me a story"
+  faas-cli invoke chat --stream -o jsonl <<< "Tell me a story"
+  faas-cli invoke env --requests 1000 --concurrency 20 --rate 50/s
+  faas-cli invoke env --duration 30s --concurrency 10 -o json
+  faas-cli invoke env --batch requests.jsonl --concurrency 5 --batch-output results.jsonl`,
 	RunE: runInvoke,
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		if len(yamlFile) > 0 {
@@ -103,6 +149,31 @@ func runInvoke(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("signing requires both --sign <header-value> and --key <key-value>")
 	}
 
+	if invokeWaitCallback && !invokeAsync {
+		return fmt.Errorf("the --wait-callback flag requires --async")
+	}
+	if len(invokeCallbackURL) > 0 && !invokeWaitCallback {
+		return fmt.Errorf("the --callback-url flag requires --wait-callback")
+	}
+	if invokeOutput == "json" && invokeWaitCallback {
+		return fmt.Errorf("the --output flag can't be used with --wait-callback")
+	}
+	if !invokeStream && invokeRequests == 0 && invokeDuration == 0 && len(invokeOutput) > 0 && invokeOutput != "json" {
+		return fmt.Errorf("the --output flag must be json, or table for a load test, or jsonl for --stream")
+	}
+	if len(invokeForm) > 0 && len(invokeDataURLEncode) > 0 {
+		return fmt.Errorf("the --form and --data-urlencode flags can't be used together")
+	}
+	if (len(invokeForm) > 0 || len(invokeDataURLEncode) > 0) && (len(invokeBatch) > 0 || cmd.Flag("content-type").Changed) {
+		return fmt.Errorf("the --form and --data-urlencode flags can't be used with --batch or --content-type")
+	}
+	if invokeStream && (invokeAsync || invokeRequests > 0 || invokeDuration > 0 || len(invokeBatch) > 0) {
+		return fmt.Errorf("the --stream flag can't be used with --async, --requests, --duration or --batch")
+	}
+	if len(invokeBatch) > 0 && (invokeWaitCallback || invokeVerbose || len(invokeOutput) > 0) {
+		return fmt.Errorf("the --batch flag can't be used with --wait-callback, --verbose or --output")
+	}
+
 	err := validateHTTPMethod(httpMethod)
 	if err != nil {
 		return nil
@@ -124,14 +195,42 @@ func runInvoke(cmd *cobra.Command, args []string) error {
 
 	httpHeader.Set("User-Agent", fmt.Sprintf("faas-cli/%s (openfaas; %s; %s)", version.BuildVersion(), runtime.GOOS, runtime.GOARCH))
 
-	stat, _ := os.Stdin.Stat()
-	if (stat.Mode() & os.ModeCharDevice) != 0 {
-		fmt.Fprintf(os.Stderr, "Reading from STDIN - hit (Control + D) to stop.\n")
+	if len(invokeBatch) > 0 {
+		if invokeRequests > 0 || invokeDuration > 0 {
+			return fmt.Errorf("the --batch flag can't be used with --requests or --duration")
+		}
+
+		client, err := GetDefaultSDKClient()
+		if err != nil {
+			return err
+		}
+
+		return runInvokeBatch(client, functionName, functionNamespace, invokeRequest{
+			Method: httpMethod,
+			Query:  httpQuery,
+			Header: httpHeader,
+			Async:  invokeAsync,
+		})
 	}
 
-	functionInput, err := io.ReadAll(os.Stdin)
-	if err != nil {
-		return fmt.Errorf("unable to read standard input: %s", err.Error())
+	var functionInput []byte
+	if len(invokeForm) > 0 || len(invokeDataURLEncode) > 0 {
+		body, formContentType, err := buildFormBody(invokeForm, invokeDataURLEncode)
+		if err != nil {
+			return err
+		}
+		functionInput = body
+		httpHeader.Set("Content-Type", formContentType)
+	} else {
+		stat, _ := os.Stdin.Stat()
+		if (stat.Mode() & os.ModeCharDevice) != 0 {
+			fmt.Fprintf(os.Stderr, "Reading from STDIN - hit (Control + D) to stop.\n")
+		}
+
+		functionInput, err = io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("unable to read standard input: %s", err.Error())
+		}
 	}
 
 	if len(sigHeader) > 0 {
@@ -139,22 +238,42 @@ func runInvoke(cmd *cobra.Command, args []string) error {
 		httpHeader.Add(sigHeader, sig)
 	}
 
-	client, err := GetDefaultSDKClient()
+	// A stream is read until the function ends it or Control + C is hit
+	timeout := commandTimeout
+	if invokeStream {
+		timeout = 0
+	}
+
+	client, err := getDefaultSDKClient(timeout)
 	if err != nil {
 		return err
 	}
 
-	u, _ := url.Parse("/")
-	u.RawQuery = httpQuery.Encode()
+	invocation := invokeRequest{
+		Method: httpMethod,
+		Query:  httpQuery,
+		Header: httpHeader,
+		Body:   functionInput,
+		Async:  invokeAsync,
+	}
 
-	body := bytes.NewReader(functionInput)
-	req, err := http.NewRequest(httpMethod, u.String(), body)
-	if err != nil {
-		return err
+	if invokeRequests > 0 || invokeDuration > 0 {
+		return runInvokeLoad(client, functionName, functionNamespace, invocation)
+	}
+
+	if invokeWaitCallback {
+		return runInvokeWaitCallback(client, functionName, functionNamespace, invocation)
+	}
+
+	if invokeStream {
+		return runInvokeStream(client, functionName, functionNamespace, invocation)
 	}
-	req.Header = httpHeader
 
-	res, err := client.InvokeFunction(functionName, functionNamespace, invokeAsync, authenticate, req)
+	trace := &invokeTrace{}
+	ctx := httptrace.WithClientTrace(context.Background(), trace.clientTrace())
+
+	start := time.Now()
+	res, err := invokeFunction(ctx, client, functionName, functionNamespace, invocation)
 	if err != nil {
 		return fmt.Errorf("failed to invoke function: %s", err)
 	}
@@ -162,29 +281,22 @@ func runInvoke(cmd *cobra.Command, args []string) error {
 		defer res.Body.Close()
 	}
 
-	if !authenticate && res.StatusCode == http.StatusUnauthorized {
-		authenticateHeader := res.Header.Get("WWW-Authenticate")
-		realm := getRealm(authenticateHeader)
-
-		// Retry the request and authenticate with an OpenFaaS function access token if the realm directive in the
-		// WWW-Authenticate header is the function invoke realm.
-		if realm == functionInvokeRealm {
-			authenticate := true
-			body := bytes.NewReader(functionInput)
-			req, err := http.NewRequest(httpMethod, u.String(), body)
-			if err != nil {
-				return err
-			}
-			req.Header = httpHeader
+	if invokeVerbose {
+		printVerboseRequest(os.Stderr, res.Request, trace)
+		printVerboseResponse(os.Stderr, res)
+		defer func() {
+			printVerboseTimings(os.Stderr, trace.timings(time.Now()))
+		}()
+	}
 
-			res, err = client.InvokeFunction(functionName, functionInvokeNamespace, invokeAsync, authenticate, req)
-			if err != nil {
-				return fmt.Errorf("failed to invoke function: %s", err)
-			}
-			if res.Body != nil {
-				defer res.Body.Close()
-			}
+	// The envelope is printed whatever the status, so that scripts can check it
+	if invokeOutput == "json" {
+		resBody, err := io.ReadAll(res.Body)
+		if err != nil {
+			return fmt.Errorf("cannot read result from OpenFaaS on URL: %s %s", gateway, err)
 		}
+
+		return printInvokeEnvelope(os.Stdout, res, resBody, time.Since(start))
 	}
 
 	if code := res.StatusCode; code < 200 || code > 299 {
@@ -208,6 +320,56 @@ func runInvoke(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// invokeRequest is a single invocation of a function
+type invokeRequest struct {
+	Method string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+	Async  bool
+}
+
+// invokeFunction invokes a function through the gateway. When the function
+// responds with a challenge for the IAM function invoke realm, the request is
+// made again with an OpenFaaS function access token.
+func invokeFunction(ctx context.Context, client *sdk.Client, name, namespace string, invocation invokeRequest) (*http.Response, error) {
+	res, err := invokeFunctionOnce(ctx, client, name, namespace, invocation, authenticate)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authenticate && res.StatusCode == http.StatusUnauthorized {
+		authenticateHeader := res.Header.Get("WWW-Authenticate")
+		realm := getRealm(authenticateHeader)
+
+		// Retry the request and authenticate with an OpenFaaS function access token if the realm directive in the
+		// WWW-Authenticate header is the function invoke realm.
+		if realm == functionInvokeRealm {
+			if res.Body != nil {
+				io.Copy(io.Discard, res.Body)
+				res.Body.Close()
+			}
+
+			return invokeFunctionOnce(ctx, client, name, namespace, invocation, true)
+		}
+	}
+
+	return res, nil
+}
+
+func invokeFunctionOnce(ctx context.Context, client *sdk.Client, name, namespace string, invocation invokeRequest, auth bool) (*http.Response, error) {
+	u, _ := url.Parse("/")
+	u.RawQuery = invocation.Query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, invocation.Method, u.String(), bytes.NewReader(invocation.Body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header = invocation.Header.Clone()
+
+	return client.InvokeFunction(name, namespace, invocation.Async, auth, req)
+}
+
 func generateSignature(message []byte, key string) string {
 	hash := hmac.Sign(message, []byte(key), crypto.SHA256.New)
 	signature := hex.EncodeToString(hash)
@@ -0,0 +1,385 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/openfaas/go-sdk"
+)
+
+var (
+	invokeBatch        string
+	invokeBatchOutput  string
+	invokeBatchBodyDir string
+)
+
+// csvHeaderPrefix marks a CSV column which holds the value of a header
+const csvHeaderPrefix = "header:"
+
+var unsafeFileChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// batchLine is a single request read from a batch file. Any field which is
+// not set falls back to the flags given to invoke.
+type batchLine struct {
+	ID      string            `json:"id"`
+	Method  string            `json:"method"`
+	Headers map[string]string `json:"headers"`
+	Query   map[string]string `json:"query"`
+	Body    json.RawMessage   `json:"body"`
+
+	line int
+	body []byte
+}
+
+// batchResult is written as one line of JSON for each line of a batch
+type batchResult struct {
+	ID           string      `json:"id"`
+	Line         int         `json:"line"`
+	Status       int         `json:"status,omitempty"`
+	Headers      http.Header `json:"headers,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"bodyEncoding,omitempty"`
+	BodyFile     string      `json:"bodyFile,omitempty"`
+	CallID       string      `json:"callId,omitempty"`
+	DurationMs   float64     `json:"durationMs"`
+	Error        string      `json:"error,omitempty"`
+}
+
+// runInvokeBatch invokes the function once for each line of the batch file,
+// with at most --concurrency requests at once, and writes a result for each
+// line as it completes.
+func runInvokeBatch(client *sdk.Client, name, namespace string, defaults invokeRequest) error {
+	if invokeConcurrency < 1 {
+		return fmt.Errorf("the --concurrency flag must be 1 or greater")
+	}
+
+	lines, err := readBatchFile(invokeBatch)
+	if err != nil {
+		return err
+	}
+
+	if len(invokeBatchBodyDir) > 0 {
+		if err := os.MkdirAll(invokeBatchBodyDir, 0700); err != nil {
+			return fmt.Errorf("unable to create --batch-body-dir: %w", err)
+		}
+	}
+
+	out := io.Writer(os.Stdout)
+	if len(invokeBatchOutput) > 0 {
+		f, err := os.Create(invokeBatchOutput)
+		if err != nil {
+			return fmt.Errorf("unable to create --batch-output: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	invocations := make([]invokeRequest, len(lines))
+	for i, line := range lines {
+		invocation, err := line.invocation(defaults)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line.line, err)
+		}
+		invocations[i] = invocation
+	}
+
+	fmt.Fprintf(os.Stderr, "Invoking %s for %d line(s) of %s.\n", functionRef(name, namespace), len(lines), invokeBatch)
+
+	failed := runBatch(context.Background(), out, lines, invokeConcurrency, func(ctx context.Context, i int) batchResult {
+		return invokeBatchLine(ctx, client, name, namespace, lines[i], invocations[i])
+	})
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d request(s) failed", failed, len(lines))
+	}
+
+	fmt.Fprintf(os.Stderr, "Completed %d request(s).\n", len(lines))
+	return nil
+}
+
+// runBatch calls call for each line from concurrency workers, writing the
+// results in the order they complete. It returns the number of results which
+// were an error or a non-2xx status.
+func runBatch(ctx context.Context, out io.Writer, lines []batchLine, concurrency int, call func(ctx context.Context, i int) batchResult) int {
+	jobs := make(chan int)
+	go func() {
+		defer close(jobs)
+		for i := range lines {
+			jobs <- i
+		}
+	}()
+
+	var mu sync.Mutex
+	failed := 0
+	encoder := json.NewEncoder(out)
+	encoder.SetEscapeHTML(false)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result := call(ctx, i)
+
+				mu.Lock()
+				if len(result.Error) > 0 || result.Status < 200 || result.Status > 299 {
+					failed++
+				}
+				if err := encoder.Encode(result); err != nil {
+					fmt.Fprintf(os.Stderr, "unable to write the result of line %d: %s\n", result.Line, err)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return failed
+}
+
+func invokeBatchLine(ctx context.Context, client *sdk.Client, name, namespace string, line batchLine, invocation invokeRequest) batchResult {
+	result := batchResult{
+		ID:   line.ID,
+		Line: line.line,
+	}
+
+	start := time.Now()
+	res, err := invokeFunction(ctx, client, name, namespace, invocation)
+	if err != nil {
+		result.DurationMs = milliseconds(time.Since(start))
+		result.Error = err.Error()
+		return result
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	result.DurationMs = milliseconds(time.Since(start))
+	result.Status = res.StatusCode
+	result.Headers = res.Header
+	result.CallID = res.Header.Get("X-Call-Id")
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	if len(invokeBatchBodyDir) > 0 {
+		result.BodyFile = filepath.Join(invokeBatchBodyDir, unsafeFileChars.ReplaceAllString(line.ID, "_")+".body")
+		if err := os.WriteFile(result.BodyFile, body, 0600); err != nil {
+			result.Error = err.Error()
+		}
+		return result
+	}
+
+	result.Body, result.BodyEncoding = encodeBody(body)
+	return result
+}
+
+// encodeBody returns a body as a string for JSON output, base64 encoded when
+// it is not valid UTF-8.
+func encodeBody(body []byte) (string, string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+	return base64.StdEncoding.EncodeToString(body), "base64"
+}
+
+// invocation merges the line into the request built from the flags
+func (line batchLine) invocation(defaults invokeRequest) (invokeRequest, error) {
+	invocation := invokeRequest{
+		Method: defaults.Method,
+		Query:  url.Values{},
+		Header: defaults.Header.Clone(),
+		Body:   line.body,
+		Async:  defaults.Async,
+	}
+
+	if len(line.Method) > 0 {
+		invocation.Method = strings.ToUpper(line.Method)
+		if err := validateHTTPMethod(invocation.Method); err != nil {
+			return invocation, err
+		}
+	}
+
+	for key, values := range defaults.Query {
+		invocation.Query[key] = append([]string{}, values...)
+	}
+	for key, value := range line.Query {
+		invocation.Query.Set(key, value)
+	}
+	for key, value := range line.Headers {
+		invocation.Header.Set(key, value)
+	}
+
+	if len(sigHeader) > 0 {
+		invocation.Header.Set(sigHeader, generateSignature(invocation.Body, key))
+	}
+
+	return invocation, nil
+}
+
+// readBatchFile reads a batch as CSV when the file name ends in .csv, and as
+// JSONL otherwise. Lines without an id are given their line number.
+func readBatchFile(name string) ([]batchLine, error) {
+	var in io.Reader
+	if name == "-" {
+		in = os.Stdin
+	} else {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read --batch file: %w", err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	var lines []batchLine
+	var err error
+	if strings.EqualFold(filepath.Ext(name), ".csv") {
+		lines, err = parseBatchCSV(in)
+	} else {
+		lines, err = parseBatchJSONL(in)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]int{}
+	for i := range lines {
+		if len(lines[i].ID) == 0 {
+			lines[i].ID = strconv.Itoa(lines[i].line)
+		}
+		if previous, ok := seen[lines[i].ID]; ok {
+			return nil, fmt.Errorf("line %d: id %q was already used on line %d", lines[i].line, lines[i].ID, previous)
+		}
+		seen[lines[i].ID] = lines[i].line
+	}
+
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("no requests found in %s", name)
+	}
+	return lines, nil
+}
+
+// parseBatchJSONL reads one JSON object per line, blank lines are skipped.
+// The body may be a string, or any other JSON value which is sent as JSON.
+func parseBatchJSONL(in io.Reader) ([]batchLine, error) {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	lines := []batchLine{}
+	for n := 1; scanner.Scan(); n++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		line := batchLine{line: n}
+		if err := json.Unmarshal(text, &line); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+
+		if len(line.Body) > 0 && line.Body[0] == '"' {
+			var body string
+			if err := json.Unmarshal(line.Body, &body); err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			line.body = []byte(body)
+		} else if len(line.Body) > 0 && string(line.Body) != "null" {
+			line.body = []byte(line.Body)
+		}
+
+		lines = append(lines, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// parseBatchCSV reads a CSV file with a header row. The columns id, method,
+// body and query are read, query holding URL encoded values such as
+// a=1&b=2, and a column named header:NAME sets the NAME header.
+func parseBatchCSV(in io.Reader) ([]batchLine, error) {
+	reader := csv.NewReader(in)
+	reader.FieldsPerRecord = -1
+
+	columns, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the CSV header row: %w", err)
+	}
+
+	for _, column := range columns {
+		switch {
+		case column == "id", column == "method", column == "body", column == "query":
+		case strings.HasPrefix(column, csvHeaderPrefix) && len(column) > len(csvHeaderPrefix):
+		default:
+			return nil, fmt.Errorf("unknown CSV column %q, use id, method, body, query or %sNAME", column, csvHeaderPrefix)
+		}
+	}
+
+	lines := []batchLine{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		row, _ := reader.FieldPos(0)
+		line := batchLine{line: row, Headers: map[string]string{}, Query: map[string]string{}}
+
+		for i, value := range record {
+			if i >= len(columns) {
+				return nil, fmt.Errorf("line %d: more values than columns", row)
+			}
+
+			switch column := columns[i]; {
+			case column == "id":
+				line.ID = value
+			case column == "method":
+				line.Method = value
+			case column == "body":
+				line.body = []byte(value)
+			case column == "query":
+				values, err := url.ParseQuery(value)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: invalid query: %w", row, err)
+				}
+				for key := range values {
+					line.Query[key] = values.Get(key)
+				}
+			default:
+				if len(value) > 0 {
+					line.Headers[strings.TrimPrefix(column, csvHeaderPrefix)] = value
+				}
+			}
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines, nil
+}
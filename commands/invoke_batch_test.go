@@ -0,0 +1,256 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_parseBatchJSONL(t *testing.T) {
+	in := `{"id":"a","method":"GET","headers":{"X-Tenant":"t1"},"query":{"v":"2"},"body":"hello"}
+
+{"body":{"name":"openfaas"}}
+`
+	lines, err := parseBatchJSONL(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(lines) != 2 {
+		t.Fatalf("want 2 lines, got: %d", len(lines))
+	}
+	if lines[0].ID != "a" || lines[0].Method != "GET" || string(lines[0].body) != "hello" || lines[0].line != 1 {
+		t.Fatalf("unexpected first line: %+v", lines[0])
+	}
+	if lines[0].Headers["X-Tenant"] != "t1" || lines[0].Query["v"] != "2" {
+		t.Fatalf("unexpected headers or query: %+v", lines[0])
+	}
+	if string(lines[1].body) != `{"name":"openfaas"}` || lines[1].line != 3 {
+		t.Fatalf("want a JSON body from line 3, got: %q from line %d", string(lines[1].body), lines[1].line)
+	}
+}
+
+func Test_parseBatchJSONL_Invalid(t *testing.T) {
+	_, err := parseBatchJSONL(strings.NewReader("{\"id\":\"a\"}\nnot json\n"))
+	if err == nil || !strings.HasPrefix(err.Error(), "line 2:") {
+		t.Fatalf("want an error for line 2, got: %v", err)
+	}
+}
+
+func Test_parseBatchCSV(t *testing.T) {
+	in := `id,method,body,query,header:X-Tenant
+a,PUT,hello,v=2&debug=1,t1
+b,,"with, comma",,
+`
+	lines, err := parseBatchCSV(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(lines) != 2 {
+		t.Fatalf("want 2 lines, got: %d", len(lines))
+	}
+	if lines[0].ID != "a" || lines[0].Method != "PUT" || string(lines[0].body) != "hello" || lines[0].line != 2 {
+		t.Fatalf("unexpected first line: %+v", lines[0])
+	}
+	if lines[0].Query["v"] != "2" || lines[0].Query["debug"] != "1" || lines[0].Headers["X-Tenant"] != "t1" {
+		t.Fatalf("unexpected headers or query: %+v", lines[0])
+	}
+	if string(lines[1].body) != "with, comma" || len(lines[1].Headers) != 0 {
+		t.Fatalf("unexpected second line: %+v", lines[1])
+	}
+}
+
+func Test_parseBatchCSV_UnknownColumn(t *testing.T) {
+	if _, err := parseBatchCSV(strings.NewReader("id,payload\na,b\n")); err == nil {
+		t.Fatalf("want an error for an unknown column")
+	}
+}
+
+func Test_readBatchFile_IDs(t *testing.T) {
+	dir := t.TempDir()
+
+	name := filepath.Join(dir, "requests.jsonl")
+	os.WriteFile(name, []byte("{\"body\":\"a\"}\n{\"id\":\"x\"}\n"), 0600)
+
+	lines, err := readBatchFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines[0].ID != "1" || lines[1].ID != "x" {
+		t.Fatalf("want ids 1 and x, got: %s and %s", lines[0].ID, lines[1].ID)
+	}
+
+	os.WriteFile(name, []byte("{\"id\":\"x\"}\n{\"id\":\"x\"}\n"), 0600)
+	if _, err := readBatchFile(name); err == nil {
+		t.Fatalf("want an error for a duplicate id")
+	}
+}
+
+func Test_batchLine_invocation(t *testing.T) {
+	defaults := invokeRequest{
+		Method: http.MethodPost,
+		Query:  url.Values{"region": []string{"eu"}},
+		Header: http.Header{"Content-Type": []string{"text/plain"}},
+	}
+
+	line := batchLine{
+		Method:  "get",
+		Headers: map[string]string{"Content-Type": "application/json"},
+		Query:   map[string]string{"v": "2"},
+		body:    []byte("{}"),
+	}
+
+	invocation, err := line.invocation(defaults)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if invocation.Method != http.MethodGet {
+		t.Fatalf("want GET, got: %s", invocation.Method)
+	}
+	if invocation.Query.Get("region") != "eu" || invocation.Query.Get("v") != "2" {
+		t.Fatalf("want the query merged, got: %v", invocation.Query)
+	}
+	if invocation.Header.Get("Content-Type") != "application/json" || defaults.Header.Get("Content-Type") != "text/plain" {
+		t.Fatalf("want the line's header to override a copy of the defaults, got: %v and %v", invocation.Header, defaults.Header)
+	}
+
+	if _, err := (batchLine{Method: "TRACE"}).invocation(defaults); err == nil {
+		t.Fatalf("want an error for an invalid method")
+	}
+}
+
+func Test_invoke_Batch(t *testing.T) {
+	resetForTest()
+	defer func() {
+		invokeBatch = ""
+		invokeBatchOutput = ""
+		invokeBatchBodyDir = ""
+		invokeAsync = false
+	}()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		w.Header().Set("X-Call-Id", "call-"+string(body))
+		if r.URL.Path == "/async-function/env.openfaas-fn" {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		if string(body) == "fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		w.Write([]byte(r.Method + " " + string(body)))
+	}))
+	defer s.Close()
+
+	dir := t.TempDir()
+	batchFile := filepath.Join(dir, "requests.jsonl")
+	os.WriteFile(batchFile, []byte(`{"id":"one","body":"1"}
+{"id":"two","method":"PUT","body":"2"}
+{"id":"three","body":"fail"}
+`), 0600)
+	outputFile := filepath.Join(dir, "results.jsonl")
+
+	faasCmd.SetArgs([]string{
+		"invoke",
+		"--gateway=" + s.URL,
+		"--batch=" + batchFile,
+		"--batch-output=" + outputFile,
+		"--concurrency=2",
+		"env",
+	})
+	err := faasCmd.Execute()
+	if err == nil || err.Error() != "1 of 3 request(s) failed" {
+		t.Fatalf("want one failed request, got: %v", err)
+	}
+
+	results := readBatchResults(t, outputFile)
+	if len(results) != 3 {
+		t.Fatalf("want 3 results, got: %d", len(results))
+	}
+	if results["one"].Body != "POST 1" || results["one"].Status != http.StatusOK || results["one"].Line != 1 {
+		t.Fatalf("unexpected result for one: %+v", results["one"])
+	}
+	if results["two"].Body != "PUT 2" {
+		t.Fatalf("unexpected result for two: %+v", results["two"])
+	}
+	if results["three"].Status != http.StatusInternalServerError {
+		t.Fatalf("unexpected result for three: %+v", results["three"])
+	}
+
+	os.WriteFile(batchFile, []byte(`{"id":"one","body":"1"}`+"\n"), 0600)
+	faasCmd.SetArgs([]string{
+		"invoke",
+		"--gateway=" + s.URL,
+		"--batch=" + batchFile,
+		"--batch-output=" + outputFile,
+		"--async",
+		"env",
+	})
+	if err := faasCmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	results = readBatchResults(t, outputFile)
+	if results["one"].Status != http.StatusAccepted || results["one"].CallID != "call-1" {
+		t.Fatalf("want the call id of the async request, got: %+v", results["one"])
+	}
+}
+
+func readBatchResults(t *testing.T, name string) map[string]batchResult {
+	t.Helper()
+
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	results := map[string]batchResult{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		result := batchResult{}
+		if err := json.Unmarshal(scanner.Bytes(), &result); err != nil {
+			t.Fatalf("invalid result %q: %s", scanner.Text(), err)
+		}
+		results[result.ID] = result
+	}
+	return results
+}
+
+func Test_invoke_Batch_UnsupportedFlags(t *testing.T) {
+	cases := [][]string{
+		{"--async", "--wait-callback"},
+		{"--verbose"},
+		{"--output", "json"},
+	}
+
+	defer func() {
+		invokeBatch = ""
+		invokeAsync, invokeWaitCallback, invokeVerbose, invokeOutput = false, false, false, ""
+	}()
+
+	for _, args := range cases {
+		resetForTest()
+		invokeAsync, invokeWaitCallback, invokeVerbose, invokeOutput = false, false, false, ""
+
+		faasCmd.SetArgs(append([]string{"invoke", "--batch", "requests.jsonl", "figlet"}, args...))
+		err := faasCmd.Execute()
+
+		if err == nil || !strings.Contains(err.Error(), "--batch flag can't be used") {
+			t.Errorf("%v: want an error for --batch, got: %v", args, err)
+		}
+	}
+}
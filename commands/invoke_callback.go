@@ -0,0 +1,181 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/openfaas/go-sdk"
+)
+
+var (
+	invokeWaitCallback    bool
+	invokeCallbackAddr    string
+	invokeCallbackURL     string
+	invokeCallbackTimeout time.Duration
+)
+
+// asyncCallback is a result posted back by the queue-worker
+type asyncCallback struct {
+	CallID string
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+// callbackReceiver accepts the callbacks of async invocations on a local
+// listener. Callbacks are queued, so that one which arrives before the call
+// ID of the request is known is not lost.
+type callbackReceiver struct {
+	listener  net.Listener
+	server    *http.Server
+	callbacks chan asyncCallback
+}
+
+func newCallbackReceiver(addr string) (*callbackReceiver, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to listen for the callback on %s: %w", addr, err)
+	}
+
+	r := &callbackReceiver{
+		listener:  listener,
+		callbacks: make(chan asyncCallback, 16),
+	}
+	r.server = &http.Server{Handler: http.HandlerFunc(r.handle)}
+
+	go r.server.Serve(listener)
+	return r, nil
+}
+
+func (r *callbackReceiver) handle(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	status, _ := strconv.Atoi(req.Header.Get("X-Function-Status"))
+
+	select {
+	case r.callbacks <- asyncCallback{
+		CallID: req.Header.Get("X-Call-Id"),
+		Status: status,
+		Header: req.Header,
+		Body:   body,
+	}:
+	default:
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// URL returns the address to give in X-Callback-Url. When the listener is
+// bound to every interface, the host is the address of the interface used to
+// reach the gateway.
+func (r *callbackReceiver) URL(gatewayURL *url.URL) string {
+	host, port, _ := net.SplitHostPort(r.listener.Addr().String())
+
+	if ip := net.ParseIP(host); ip == nil || ip.IsUnspecified() {
+		host = outboundHost(gatewayURL)
+	}
+
+	return (&url.URL{Scheme: "http", Host: net.JoinHostPort(host, port), Path: "/"}).String()
+}
+
+// Wait returns the callback for callID, or the first callback when callID is
+// empty, such as for a gateway which does not return X-Call-Id.
+func (r *callbackReceiver) Wait(ctx context.Context, callID string) (asyncCallback, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return asyncCallback{}, ctx.Err()
+		case callback := <-r.callbacks:
+			if len(callID) == 0 || callback.CallID == callID {
+				return callback, nil
+			}
+			fmt.Fprintf(os.Stderr, "Ignoring a callback for call %s\n", callback.CallID)
+		}
+	}
+}
+
+func (r *callbackReceiver) Close() error {
+	return r.server.Close()
+}
+
+// outboundHost finds the local address used to reach the gateway. No packets
+// are sent, as dialing UDP only picks a route.
+func outboundHost(gatewayURL *url.URL) string {
+	port := gatewayURL.Port()
+	if len(port) == 0 {
+		port = "80"
+	}
+
+	conn, err := net.Dial("udp", net.JoinHostPort(gatewayURL.Hostname(), port))
+	if err != nil {
+		return "127.0.0.1"
+	}
+	defer conn.Close()
+
+	return conn.LocalAddr().(*net.UDPAddr).IP.String()
+}
+
+// runInvokeWaitCallback submits an async invocation with X-Callback-Url set
+// to a local listener, then prints the result posted back by the
+// queue-worker.
+func runInvokeWaitCallback(client *sdk.Client, name, namespace string, invocation invokeRequest) error {
+	receiver, err := newCallbackReceiver(invokeCallbackAddr)
+	if err != nil {
+		return err
+	}
+	defer receiver.Close()
+
+	callbackURL := invokeCallbackURL
+	if len(callbackURL) == 0 {
+		callbackURL = receiver.URL(client.GatewayURL)
+	}
+
+	invocation.Async = true
+	invocation.Header = invocation.Header.Clone()
+	invocation.Header.Set("X-Callback-Url", callbackURL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), invokeCallbackTimeout)
+	defer cancel()
+
+	res, err := invokeFunction(ctx, client, name, namespace, invocation)
+	if err != nil {
+		return fmt.Errorf("failed to invoke function: %s", err)
+	}
+	resBody, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+
+	if res.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("server returned unexpected status code: %d - %s", res.StatusCode, string(resBody))
+	}
+
+	callID := res.Header.Get("X-Call-Id")
+	fmt.Fprintf(os.Stderr, "Function submitted asynchronously with call ID %s, waiting for the callback on %s\n", callID, callbackURL)
+
+	callback, err := receiver.Wait(ctx, callID)
+	if err != nil {
+		return fmt.Errorf("timed out after %s waiting for the callback of call %s", invokeCallbackTimeout, callID)
+	}
+
+	fmt.Fprintf(os.Stderr, "Callback received for call %s: status %d, took %ss\n", callback.CallID, callback.Status, callback.Header.Get("X-Duration-Seconds"))
+
+	if callback.Status != 0 && (callback.Status < 200 || callback.Status > 299) {
+		return fmt.Errorf("function returned unexpected status code: %d - %s", callback.Status, string(callback.Body))
+	}
+
+	os.Stdout.Write(callback.Body)
+	return nil
+}
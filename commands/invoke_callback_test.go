@@ -0,0 +1,160 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openfaas/faas-cli/test"
+)
+
+// newAsyncGateway returns a gateway which accepts async invocations and posts
+// the result to X-Callback-Url, after first posting a callback for another
+// call.
+func newAsyncGateway(t *testing.T, status string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/async-function/figlet.openfaas-fn" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		callbackURL := r.Header.Get("X-Callback-Url")
+
+		go func() {
+			post := func(callID, result string) {
+				req, _ := http.NewRequest(http.MethodPost, callbackURL, strings.NewReader(result))
+				req.Header.Set("X-Call-Id", callID)
+				req.Header.Set("X-Function-Status", status)
+				req.Header.Set("X-Duration-Seconds", "0.100")
+				if res, err := http.DefaultClient.Do(req); err == nil {
+					res.Body.Close()
+				}
+			}
+
+			post("other-call", "not this one")
+			post("call-1", "_"+string(body)+"_")
+		}()
+
+		w.Header().Set("X-Call-Id", "call-1")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+}
+
+func Test_invoke_WaitCallback(t *testing.T) {
+	resetForTest()
+	defer func() {
+		invokeAsync = false
+		invokeWaitCallback = false
+		invokeCallbackAddr = ":0"
+	}()
+
+	s := newAsyncGateway(t, "200")
+	defer s.Close()
+
+	os.Stdin, _ = os.CreateTemp("", "stdin")
+	os.Stdin.WriteString("hello")
+	os.Stdin.Seek(0, 0)
+	defer os.Remove(os.Stdin.Name())
+
+	var err error
+	stdOut := test.CaptureStdout(func() {
+		faasCmd.SetArgs([]string{
+			"invoke",
+			"--gateway=" + s.URL,
+			"--async",
+			"--wait-callback",
+			"--callback-addr=127.0.0.1:0",
+			"--callback-timeout=5s",
+			"figlet",
+		})
+		err = faasCmd.Execute()
+	})
+
+	if err != nil {
+		t.Fatalf("want no error, got: %s", err)
+	}
+	if stdOut != "_hello_" {
+		t.Fatalf("want the callback body, got: %q", stdOut)
+	}
+}
+
+func Test_invoke_WaitCallback_FunctionError(t *testing.T) {
+	resetForTest()
+	defer func() {
+		invokeAsync = false
+		invokeWaitCallback = false
+		invokeCallbackAddr = ":0"
+	}()
+
+	s := newAsyncGateway(t, "500")
+	defer s.Close()
+
+	os.Stdin, _ = os.CreateTemp("", "stdin")
+	defer os.Remove(os.Stdin.Name())
+
+	faasCmd.SetArgs([]string{
+		"invoke",
+		"--gateway=" + s.URL,
+		"--async",
+		"--wait-callback",
+		"--callback-addr=127.0.0.1:0",
+		"figlet",
+	})
+	err := faasCmd.Execute()
+
+	if err == nil || !strings.Contains(err.Error(), "unexpected status code: 500") {
+		t.Fatalf("want the function's status in the error, got: %v", err)
+	}
+}
+
+func Test_invoke_WaitCallback_RequiresAsync(t *testing.T) {
+	resetForTest()
+	defer func() { invokeWaitCallback = false }()
+
+	faasCmd.SetArgs([]string{"invoke", "--wait-callback", "figlet"})
+	err := faasCmd.Execute()
+
+	if err == nil || err.Error() != "the --wait-callback flag requires --async" {
+		t.Fatalf("want an error for a missing --async, got: %v", err)
+	}
+}
+
+func Test_callbackReceiver_Timeout(t *testing.T) {
+	receiver, err := newCallbackReceiver("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer receiver.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := receiver.Wait(ctx, "call-1"); err == nil {
+		t.Fatalf("want a timeout")
+	}
+}
+
+func Test_callbackReceiver_URL(t *testing.T) {
+	receiver, err := newCallbackReceiver(":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer receiver.Close()
+
+	gatewayURL, _ := url.Parse("http://127.0.0.1:8080")
+	got, _ := url.Parse(receiver.URL(gatewayURL))
+
+	if got.Hostname() != "127.0.0.1" || len(got.Port()) == 0 {
+		t.Fatalf("want the loopback address used to reach the gateway, got: %s", got)
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	invokeForm          []string
+	invokeDataURLEncode []string
+)
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// buildFormBody encodes the --form fields as multipart/form-data, or the
+// --data-urlencode fields as application/x-www-form-urlencoded. The whole
+// body is built up front, so that --sign can cover it.
+func buildFormBody(form, dataURLEncode []string) ([]byte, string, error) {
+	if len(form) > 0 {
+		return buildMultipartBody(form)
+	}
+
+	body, err := buildURLEncodedBody(dataURLEncode)
+	if err != nil {
+		return nil, "", err
+	}
+	return body, "application/x-www-form-urlencoded", nil
+}
+
+// buildMultipartBody accepts fields in the forms used by curl -F:
+// key=value, key=<path to read the value from a file, and key=@path to
+// upload a file, optionally followed by ;type=MIME and ;filename=NAME.
+func buildMultipartBody(fields []string) ([]byte, string, error) {
+	var b bytes.Buffer
+	w := multipart.NewWriter(&b)
+
+	for _, field := range fields {
+		name, value, ok := strings.Cut(field, "=")
+		if !ok || len(name) == 0 {
+			return nil, "", fmt.Errorf("the --form flag must take the form of key=value, key=<path or key=@path (given %q)", field)
+		}
+
+		switch {
+		case strings.HasPrefix(value, "@"):
+			if err := writeFilePart(w, name, value[1:]); err != nil {
+				return nil, "", err
+			}
+
+		case strings.HasPrefix(value, "<"):
+			data, err := os.ReadFile(value[1:])
+			if err != nil {
+				return nil, "", fmt.Errorf("unable to read --form %s: %w", name, err)
+			}
+			if err := w.WriteField(name, string(data)); err != nil {
+				return nil, "", err
+			}
+
+		default:
+			if err := w.WriteField(name, value); err != nil {
+				return nil, "", err
+			}
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return b.Bytes(), w.FormDataContentType(), nil
+}
+
+func writeFilePart(w *multipart.Writer, name, spec string) error {
+	parts := strings.Split(spec, ";")
+	path := parts[0]
+	if len(path) == 0 {
+		return fmt.Errorf("the --form flag needs a file path after @ for %s", name)
+	}
+
+	filename := filepath.Base(path)
+	contentType := mime.TypeByExtension(filepath.Ext(path))
+	if len(contentType) == 0 {
+		contentType = "application/octet-stream"
+	}
+
+	for _, option := range parts[1:] {
+		key, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+		switch key {
+		case "type":
+			contentType = value
+		case "filename":
+			filename = value
+		default:
+			return fmt.Errorf("unknown --form option %q for %s, use type= or filename=", key, name)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to read --form %s: %w", name, err)
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`,
+		quoteEscaper.Replace(name), quoteEscaper.Replace(filename)))
+	header.Set("Content-Type", contentType)
+
+	part, err := w.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	_, err = part.Write(data)
+	return err
+}
+
+// buildURLEncodedBody accepts key=value, or key@path to read the value from a
+// file. The fields are kept in the order they were given.
+func buildURLEncodedBody(fields []string) ([]byte, error) {
+	pairs := make([]string, 0, len(fields))
+
+	for _, field := range fields {
+		eq := strings.Index(field, "=")
+		at := strings.Index(field, "@")
+
+		var name, value string
+		switch {
+		case eq > 0 && (at < 0 || eq < at):
+			name, value = field[:eq], field[eq+1:]
+
+		case at > 0:
+			data, err := os.ReadFile(field[at+1:])
+			if err != nil {
+				return nil, fmt.Errorf("unable to read --data-urlencode %s: %w", field[:at], err)
+			}
+			name, value = field[:at], string(data)
+
+		default:
+			return nil, fmt.Errorf("the --data-urlencode flag must take the form of key=value or key@path (given %q)", field)
+		}
+
+		pairs = append(pairs, url.QueryEscape(name)+"="+url.QueryEscape(value))
+	}
+
+	return []byte(strings.Join(pairs, "&")), nil
+}
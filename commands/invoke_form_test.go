@@ -0,0 +1,128 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_buildURLEncodedBody(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "message.txt")
+	os.WriteFile(path, []byte("hello world"), 0600)
+
+	body, err := buildURLEncodedBody([]string{"email=alex@example.com", "message@" + path, "a=b=c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "email=alex%40example.com&message=hello+world&a=b%3Dc"
+	if string(body) != want {
+		t.Fatalf("want %q, got: %q", want, string(body))
+	}
+
+	if _, err := buildURLEncodedBody([]string{"no-separator"}); err == nil {
+		t.Fatalf("want an error for a field without a value")
+	}
+}
+
+func Test_buildMultipartBody(t *testing.T) {
+	dir := t.TempDir()
+	image := filepath.Join(dir, "cat.png")
+	os.WriteFile(image, []byte{0x89, 'P', 'N', 'G'}, 0600)
+	notes := filepath.Join(dir, "notes.txt")
+	os.WriteFile(notes, []byte("from a file"), 0600)
+
+	body, contentType, err := buildMultipartBody([]string{
+		"width=200",
+		"notes=<" + notes,
+		"image=@" + image + ";type=image/x-test;filename=upload.png",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "multipart/form-data" {
+		t.Fatalf("want multipart/form-data, got: %q", contentType)
+	}
+
+	form, err := multipart.NewReader(bytes.NewReader(body), params["boundary"]).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if form.Value["width"][0] != "200" || form.Value["notes"][0] != "from a file" {
+		t.Fatalf("unexpected values: %v", form.Value)
+	}
+
+	file := form.File["image"][0]
+	if file.Filename != "upload.png" || file.Header.Get("Content-Type") != "image/x-test" {
+		t.Fatalf("unexpected file part: %s %v", file.Filename, file.Header)
+	}
+
+	f, _ := file.Open()
+	data, _ := io.ReadAll(f)
+	if !bytes.Equal(data, []byte{0x89, 'P', 'N', 'G'}) {
+		t.Fatalf("unexpected file content: %v", data)
+	}
+}
+
+func Test_buildMultipartBody_Invalid(t *testing.T) {
+	cases := []string{"novalue", "=value", "image=@", "image=@missing.png", "image=@cat.png;size=1"}
+
+	for _, field := range cases {
+		if _, _, err := buildMultipartBody([]string{field}); err == nil {
+			t.Errorf("want an error for %q", field)
+		}
+	}
+}
+
+func Test_invoke_FormSigned(t *testing.T) {
+	resetForTest()
+	defer func() {
+		invokeDataURLEncode = []string{}
+		sigHeader = ""
+		key = ""
+	}()
+
+	var gotBody []byte
+	var gotSignature, gotContentType string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotSignature = r.Header.Get("X-Signature")
+		gotContentType = r.Header.Get("Content-Type")
+	}))
+	defer s.Close()
+
+	faasCmd.SetArgs([]string{
+		"invoke",
+		"--gateway=" + s.URL,
+		"--data-urlencode", "email=alex@example.com",
+		"--sign", "X-Signature",
+		"--key", "secret",
+		"subscribe",
+	})
+	if err := faasCmd.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	if string(gotBody) != "email=alex%40example.com" {
+		t.Fatalf("unexpected body: %q", string(gotBody))
+	}
+	if gotContentType != "application/x-www-form-urlencoded" {
+		t.Fatalf("unexpected content type: %q", gotContentType)
+	}
+	if want := generateSignature(gotBody, "secret"); gotSignature != want {
+		t.Fatalf("want the signature to cover the encoded body %q, got: %q", want, gotSignature)
+	}
+}
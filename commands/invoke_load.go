@@ -0,0 +1,455 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/openfaas/go-sdk"
+)
+
+var (
+	invokeRequests    int
+	invokeConcurrency int
+	invokeRate        string
+	invokeDuration    time.Duration
+	invokeOutput      string
+)
+
+// histogramBuckets is the number of bars drawn for the latency histogram
+const histogramBuckets = 10
+
+// loadTestOptions control how many requests a load test makes, and how fast
+type loadTestOptions struct {
+	// Requests is the number of requests to make, zero for no limit
+	Requests    int
+	Concurrency int
+	// Interval is the time between the start of each request, zero for no
+	// limit
+	Interval time.Duration
+	// Duration stops the load test after this long, zero for no limit
+	Duration time.Duration
+}
+
+// loadSample is the result of a single request made by a load test
+type loadSample struct {
+	Status  int
+	Latency time.Duration
+	Err     error
+
+	// FunctionDuration is read from the X-Duration-Seconds header set by
+	// the watchdog
+	FunctionDuration    time.Duration
+	HasFunctionDuration bool
+}
+
+type loadReport struct {
+	Function          string            `json:"function"`
+	Requests          int               `json:"requests"`
+	Errors            int               `json:"errors"`
+	DurationSeconds   float64           `json:"durationSeconds"`
+	RequestsPerSecond float64           `json:"requestsPerSecond"`
+	StatusCodes       map[int]int       `json:"statusCodes"`
+	ErrorMessages     map[string]int    `json:"errorMessages,omitempty"`
+	Latency           *latencySummary   `json:"latency,omitempty"`
+	FunctionDuration  *latencySummary   `json:"functionDuration,omitempty"`
+	Histogram         []histogramBucket `json:"histogram,omitempty"`
+}
+
+// latencySummary is given in milliseconds
+type latencySummary struct {
+	Min  float64 `json:"minMs"`
+	Mean float64 `json:"meanMs"`
+	P50  float64 `json:"p50Ms"`
+	P90  float64 `json:"p90Ms"`
+	P99  float64 `json:"p99Ms"`
+	Max  float64 `json:"maxMs"`
+}
+
+type histogramBucket struct {
+	UpperMs float64 `json:"upperMs"`
+	Count   int     `json:"count"`
+}
+
+// runInvokeLoad invokes the function repeatedly and prints a summary of the
+// latencies and status codes, stopping early on Control + C.
+func runInvokeLoad(client *sdk.Client, name, namespace string, invocation invokeRequest) error {
+	if invokeConcurrency < 1 {
+		return fmt.Errorf("the --concurrency flag must be 1 or greater")
+	}
+	if invokeRequests < 0 {
+		return fmt.Errorf("the --requests flag must be 0 or greater")
+	}
+	if invokeOutput != "" && invokeOutput != "table" && invokeOutput != "json" {
+		return fmt.Errorf("the --output flag must be table or json for a load test")
+	}
+
+	interval, err := parseRate(invokeRate)
+	if err != nil {
+		return err
+	}
+
+	opts := loadTestOptions{
+		Requests:    invokeRequests,
+		Concurrency: invokeConcurrency,
+		Interval:    interval,
+		Duration:    invokeDuration,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	fmt.Fprintf(os.Stderr, "Load testing %s with %d concurrent request(s), hit (Control + C) to stop.\n", functionRef(name, namespace), opts.Concurrency)
+
+	samples, elapsed := runLoadTest(ctx, opts, func(ctx context.Context) loadSample {
+		return invokeSample(ctx, client, name, namespace, invocation)
+	})
+
+	report := summariseLoadTest(samples, elapsed)
+	report.Function = functionRef(name, namespace)
+
+	if invokeOutput == "json" {
+		return printLoadReportJSON(os.Stdout, report)
+	}
+
+	printLoadReport(os.Stdout, report)
+	return nil
+}
+
+// invokeSample makes a single request for a load test, the response body is
+// read so that the latency includes the time to transfer it.
+func invokeSample(ctx context.Context, client *sdk.Client, name, namespace string, invocation invokeRequest) loadSample {
+	start := time.Now()
+
+	res, err := invokeFunction(ctx, client, name, namespace, invocation)
+	if err != nil {
+		return loadSample{Err: err, Latency: time.Since(start)}
+	}
+
+	if res.Body != nil {
+		io.Copy(io.Discard, res.Body)
+		res.Body.Close()
+	}
+
+	sample := loadSample{
+		Status:  res.StatusCode,
+		Latency: time.Since(start),
+	}
+
+	if value := res.Header.Get("X-Duration-Seconds"); len(value) > 0 {
+		if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+			sample.FunctionDuration = time.Duration(seconds * float64(time.Second))
+			sample.HasFunctionDuration = true
+		}
+	}
+
+	return sample
+}
+
+// runLoadTest calls call from opts.Concurrency workers until the number of
+// requests or the duration is reached, or ctx is cancelled. Requests in
+// flight when the duration is reached are allowed to finish.
+func runLoadTest(ctx context.Context, opts loadTestOptions, call func(ctx context.Context) loadSample) ([]loadSample, time.Duration) {
+	issueCtx := ctx
+	if opts.Duration > 0 {
+		var cancel context.CancelFunc
+		issueCtx, cancel = context.WithTimeout(ctx, opts.Duration)
+		defer cancel()
+	}
+
+	start := time.Now()
+
+	jobs := make(chan struct{})
+	go func() {
+		defer close(jobs)
+
+		var tick <-chan time.Time
+		if opts.Interval > 0 {
+			ticker := time.NewTicker(opts.Interval)
+			defer ticker.Stop()
+			tick = ticker.C
+		}
+
+		for i := 0; opts.Requests == 0 || i < opts.Requests; i++ {
+			if tick != nil && i > 0 {
+				select {
+				case <-issueCtx.Done():
+					return
+				case <-tick:
+				}
+			}
+
+			select {
+			case <-issueCtx.Done():
+				return
+			case jobs <- struct{}{}:
+			}
+		}
+	}()
+
+	results := make(chan loadSample, opts.Concurrency)
+	wg := sync.WaitGroup{}
+	for i := 0; i < opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range jobs {
+				results <- call(ctx)
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	samples := []loadSample{}
+	for sample := range results {
+		// Requests cut short by Control + C say nothing about the function
+		if sample.Err != nil && errors.Is(sample.Err, context.Canceled) {
+			continue
+		}
+		samples = append(samples, sample)
+	}
+
+	return samples, time.Since(start)
+}
+
+// parseRate parses a rate such as 50/s, 600/m or 50 into the interval between
+// requests. An empty rate means no limit.
+func parseRate(rate string) (time.Duration, error) {
+	if len(rate) == 0 {
+		return 0, nil
+	}
+
+	count, unit, found := strings.Cut(rate, "/")
+	per := time.Second
+	if found {
+		switch strings.TrimSpace(unit) {
+		case "s":
+			per = time.Second
+		case "m":
+			per = time.Minute
+		case "h":
+			per = time.Hour
+		default:
+			return 0, fmt.Errorf("the --rate flag must be a number of requests per s, m or h, such as 50/s")
+		}
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(count), 64)
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("the --rate flag must be a number of requests per s, m or h, such as 50/s")
+	}
+
+	return time.Duration(float64(per) / value), nil
+}
+
+func summariseLoadTest(samples []loadSample, elapsed time.Duration) loadReport {
+	report := loadReport{
+		Requests:        len(samples),
+		DurationSeconds: elapsed.Seconds(),
+		StatusCodes:     map[int]int{},
+	}
+
+	if elapsed > 0 {
+		report.RequestsPerSecond = float64(len(samples)) / elapsed.Seconds()
+	}
+
+	latencies := []time.Duration{}
+	functionDurations := []time.Duration{}
+	for _, sample := range samples {
+		if sample.Err != nil {
+			report.Errors++
+			if report.ErrorMessages == nil {
+				report.ErrorMessages = map[string]int{}
+			}
+			report.ErrorMessages[sample.Err.Error()]++
+			continue
+		}
+
+		report.StatusCodes[sample.Status]++
+		latencies = append(latencies, sample.Latency)
+		if sample.HasFunctionDuration {
+			functionDurations = append(functionDurations, sample.FunctionDuration)
+		}
+	}
+
+	report.Latency = summariseLatencies(latencies)
+	report.FunctionDuration = summariseLatencies(functionDurations)
+	report.Histogram = latencyHistogram(latencies, histogramBuckets)
+
+	return report
+}
+
+func summariseLatencies(latencies []time.Duration) *latencySummary {
+	if len(latencies) == 0 {
+		return nil
+	}
+
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, latency := range sorted {
+		total += latency
+	}
+
+	return &latencySummary{
+		Min:  milliseconds(sorted[0]),
+		Mean: milliseconds(total / time.Duration(len(sorted))),
+		P50:  milliseconds(percentile(sorted, 50)),
+		P90:  milliseconds(percentile(sorted, 90)),
+		P99:  milliseconds(percentile(sorted, 99)),
+		Max:  milliseconds(sorted[len(sorted)-1]),
+	}
+}
+
+// percentile uses the nearest-rank method on sorted latencies
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// latencyHistogram splits the range of latencies into evenly sized buckets
+func latencyHistogram(latencies []time.Duration, buckets int) []histogramBucket {
+	if len(latencies) == 0 {
+		return nil
+	}
+
+	low, high := latencies[0], latencies[0]
+	for _, latency := range latencies {
+		low = min(low, latency)
+		high = max(high, latency)
+	}
+
+	width := (high - low) / time.Duration(buckets)
+	if width <= 0 {
+		return []histogramBucket{{UpperMs: milliseconds(high), Count: len(latencies)}}
+	}
+
+	histogram := make([]histogramBucket, buckets)
+	for i := range histogram {
+		histogram[i].UpperMs = milliseconds(low + width*time.Duration(i+1))
+	}
+	histogram[buckets-1].UpperMs = milliseconds(high)
+
+	for _, latency := range latencies {
+		i := int((latency - low) / width)
+		if i >= buckets {
+			i = buckets - 1
+		}
+		histogram[i].Count++
+	}
+
+	return histogram
+}
+
+func milliseconds(d time.Duration) float64 {
+	return math.Round(float64(d.Microseconds())) / 1000
+}
+
+func printLoadReportJSON(w io.Writer, report loadReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, string(data))
+	return nil
+}
+
+func printLoadReport(w io.Writer, report loadReport) {
+	var b bytes.Buffer
+	tw := tabwriter.NewWriter(&b, 0, 0, 1, ' ', 0)
+
+	fmt.Fprintf(tw, "Function:\t%s\n", report.Function)
+	fmt.Fprintf(tw, "Requests:\t%d\n", report.Requests)
+	fmt.Fprintf(tw, "Errors:\t%d\n", report.Errors)
+	fmt.Fprintf(tw, "Duration:\t%.2fs\n", report.DurationSeconds)
+	fmt.Fprintf(tw, "Throughput:\t%.2f req/s\n", report.RequestsPerSecond)
+	fmt.Fprintln(tw)
+
+	codes := make([]int, 0, len(report.StatusCodes))
+	for code := range report.StatusCodes {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+
+	fmt.Fprintln(tw, "Status\tCount")
+	for _, code := range codes {
+		fmt.Fprintf(tw, "%d\t%d\n", code, report.StatusCodes[code])
+	}
+
+	messages := make([]string, 0, len(report.ErrorMessages))
+	for message := range report.ErrorMessages {
+		messages = append(messages, message)
+	}
+	sort.Strings(messages)
+	for _, message := range messages {
+		fmt.Fprintf(tw, "error\t%d\t%s\n", report.ErrorMessages[message], message)
+	}
+	fmt.Fprintln(tw)
+
+	if report.Latency != nil {
+		fmt.Fprintln(tw, "Latency (ms)\tMin\tMean\tP50\tP90\tP99\tMax")
+		printLatencyRow(tw, "request", report.Latency)
+		if report.FunctionDuration != nil {
+			printLatencyRow(tw, "function", report.FunctionDuration)
+		}
+	}
+	tw.Flush()
+
+	if len(report.Histogram) > 0 {
+		fmt.Fprintln(&b)
+		printHistogram(&b, report.Histogram)
+	}
+
+	w.Write(b.Bytes())
+}
+
+func printLatencyRow(w io.Writer, name string, summary *latencySummary) {
+	fmt.Fprintf(w, "%s\t%.2f\t%.2f\t%.2f\t%.2f\t%.2f\t%.2f\n", name,
+		summary.Min, summary.Mean, summary.P50, summary.P90, summary.P99, summary.Max)
+}
+
+// printHistogram draws a bar for each bucket, scaled to the largest bucket
+func printHistogram(w io.Writer, histogram []histogramBucket) {
+	const barWidth = 40
+
+	largest := 0
+	for _, bucket := range histogram {
+		largest = max(largest, bucket.Count)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "Latency histogram (ms):")
+	for _, bucket := range histogram {
+		bar := 0
+		if largest > 0 {
+			bar = int(math.Round(float64(bucket.Count) / float64(largest) * barWidth))
+		}
+		fmt.Fprintf(tw, "%.2f\t[%d]\t %s\n", bucket.UpperMs, bucket.Count, strings.Repeat("#", bar))
+	}
+	tw.Flush()
+}
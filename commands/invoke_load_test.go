@@ -0,0 +1,190 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openfaas/faas-cli/test"
+)
+
+func Test_parseRate(t *testing.T) {
+	cases := []struct {
+		rate    string
+		want    time.Duration
+		wantErr bool
+	}{
+		{rate: "", want: 0},
+		{rate: "50/s", want: 20 * time.Millisecond},
+		{rate: "50", want: 20 * time.Millisecond},
+		{rate: "600/m", want: 100 * time.Millisecond},
+		{rate: "0.5/s", want: 2 * time.Second},
+		{rate: "50/d", wantErr: true},
+		{rate: "fast", wantErr: true},
+		{rate: "0/s", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.rate, func(t *testing.T) {
+			got, err := parseRate(tc.rate)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("want no error, got: %s", err)
+			}
+			if got != tc.want {
+				t.Fatalf("want %s, got: %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func Test_runLoadTest_MakesRequests(t *testing.T) {
+	var calls, inFlight, maxInFlight int32
+
+	samples, _ := runLoadTest(context.Background(), loadTestOptions{Requests: 25, Concurrency: 4}, func(ctx context.Context) loadSample {
+		atomic.AddInt32(&calls, 1)
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		return loadSample{Status: http.StatusOK, Latency: time.Millisecond}
+	})
+
+	if calls != 25 || len(samples) != 25 {
+		t.Fatalf("want 25 requests, got: %d calls, %d samples", calls, len(samples))
+	}
+	if maxInFlight > 4 {
+		t.Fatalf("want at most 4 requests at once, got: %d", maxInFlight)
+	}
+}
+
+func Test_runLoadTest_StopsAfterDuration(t *testing.T) {
+	start := time.Now()
+	samples, _ := runLoadTest(context.Background(), loadTestOptions{Concurrency: 2, Interval: 5 * time.Millisecond, Duration: 50 * time.Millisecond}, func(ctx context.Context) loadSample {
+		return loadSample{Status: http.StatusOK}
+	})
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("want the load test to stop after its duration, took: %s", elapsed)
+	}
+	if len(samples) == 0 || len(samples) > 12 {
+		t.Fatalf("want around 10 requests at 5ms intervals, got: %d", len(samples))
+	}
+}
+
+func Test_summariseLoadTest(t *testing.T) {
+	samples := []loadSample{}
+	for i := 1; i <= 100; i++ {
+		status := http.StatusOK
+		if i%10 == 0 {
+			status = http.StatusBadGateway
+		}
+		samples = append(samples, loadSample{
+			Status:              status,
+			Latency:             time.Duration(i) * time.Millisecond,
+			FunctionDuration:    time.Duration(i) * time.Millisecond / 2,
+			HasFunctionDuration: true,
+		})
+	}
+	samples = append(samples, loadSample{Err: errors.New("connection refused")})
+
+	report := summariseLoadTest(samples, 2*time.Second)
+
+	if report.Requests != 101 || report.Errors != 1 || report.ErrorMessages["connection refused"] != 1 {
+		t.Fatalf("unexpected counts: %+v", report)
+	}
+	if report.StatusCodes[http.StatusOK] != 90 || report.StatusCodes[http.StatusBadGateway] != 10 {
+		t.Fatalf("unexpected status codes: %v", report.StatusCodes)
+	}
+	if report.RequestsPerSecond != 50.5 {
+		t.Fatalf("want 50.5 req/s, got: %f", report.RequestsPerSecond)
+	}
+
+	want := latencySummary{Min: 1, Mean: 50.5, P50: 50, P90: 90, P99: 99, Max: 100}
+	if *report.Latency != want {
+		t.Fatalf("want latency %+v, got: %+v", want, *report.Latency)
+	}
+	if report.FunctionDuration == nil || report.FunctionDuration.Max != 50 {
+		t.Fatalf("unexpected function duration: %+v", report.FunctionDuration)
+	}
+
+	total := 0
+	for _, bucket := range report.Histogram {
+		total += bucket.Count
+	}
+	if len(report.Histogram) != histogramBuckets || total != 100 {
+		t.Fatalf("want 100 latencies across %d buckets, got: %d in %d", histogramBuckets, total, len(report.Histogram))
+	}
+}
+
+func Test_invoke_LoadTestJSON(t *testing.T) {
+	resetForTest()
+	defer func() {
+		invokeRequests = 0
+		invokeConcurrency = 1
+		invokeOutput = ""
+	}()
+
+	var calls int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/function/env.openfaas-fn" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("X-Duration-Seconds", "0.010")
+		w.Write([]byte("ok"))
+	}))
+	defer s.Close()
+
+	os.Stdin, _ = os.CreateTemp("", "stdin")
+	os.Stdin.WriteString("test-data")
+	os.Stdin.Seek(0, 0)
+	defer os.Remove(os.Stdin.Name())
+
+	stdOut := test.CaptureStdout(func() {
+		faasCmd.SetArgs([]string{
+			"invoke",
+			"--gateway=" + s.URL,
+			"--requests=10",
+			"--concurrency=3",
+			"-o", "json",
+			"env",
+		})
+		if err := faasCmd.Execute(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	report := loadReport{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(stdOut)), &report); err != nil {
+		t.Fatalf("want a JSON report, got: %q", stdOut)
+	}
+
+	if calls != 10 || report.Requests != 10 || report.StatusCodes[http.StatusOK] != 10 {
+		t.Fatalf("want 10 successful requests, got %d calls and report: %+v", calls, report)
+	}
+	if report.FunctionDuration == nil || report.FunctionDuration.P50 != 10 {
+		t.Fatalf("want the function duration from X-Duration-Seconds, got: %+v", report.FunctionDuration)
+	}
+}
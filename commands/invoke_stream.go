@@ -0,0 +1,184 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/openfaas/go-sdk"
+)
+
+var invokeStream bool
+
+const eventStreamContentType = "text/event-stream"
+
+// sseEvent is a single server-sent event
+type sseEvent struct {
+	Event string `json:"event"`
+	ID    string `json:"id,omitempty"`
+	Data  string `json:"data"`
+}
+
+// runInvokeStream invokes the function and writes the response as it
+// arrives, or with --output jsonl, writes each server-sent event as a line of
+// JSON. Control + C cancels the request.
+func runInvokeStream(client *sdk.Client, name, namespace string, invocation invokeRequest) error {
+	if invokeOutput != "" && invokeOutput != "jsonl" {
+		return fmt.Errorf("the --output flag must be jsonl for --stream")
+	}
+
+	invocation.Header = invocation.Header.Clone()
+	if len(invocation.Header.Get("Accept")) == 0 {
+		invocation.Header.Set("Accept", eventStreamContentType)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	res, err := invokeFunction(ctx, client, name, namespace, invocation)
+	if err != nil {
+		if errors.Is(err, context.Canceled) {
+			return nil
+		}
+		return fmt.Errorf("failed to invoke function: %s", err)
+	}
+	defer res.Body.Close()
+
+	if code := res.StatusCode; code < 200 || code > 299 {
+		resBody, err := io.ReadAll(res.Body)
+		if err != nil {
+			return fmt.Errorf("cannot read result from OpenFaaS on URL: %s %s", gateway, err)
+		}
+
+		return fmt.Errorf("server returned unexpected status code: %d - %s", res.StatusCode, string(resBody))
+	}
+
+	if invokeOutput == "jsonl" && isEventStream(res) {
+		err = writeSSEAsJSONL(os.Stdout, res.Body)
+	} else {
+		if invokeOutput == "jsonl" {
+			fmt.Fprintf(os.Stderr, "The response is %q rather than server-sent events, printing it as it arrives.\n", res.Header.Get("Content-Type"))
+		}
+		err = copyStream(os.Stdout, res.Body)
+	}
+
+	// Control + C ends the stream early, which is not an error
+	if err != nil && ctx.Err() == nil {
+		return fmt.Errorf("cannot read result from OpenFaaS on URL: %s %s", gateway, err)
+	}
+	return nil
+}
+
+// copyStream writes each chunk as soon as it is read, rather than waiting to
+// fill a buffer
+func copyStream(w io.Writer, r io.Reader) error {
+	buf := make([]byte, 4096)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if _, writeErr := w.Write(buf[:n]); writeErr != nil {
+				return writeErr
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func writeSSEAsJSONL(w io.Writer, r io.Reader) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+
+	return parseSSE(r, func(event sseEvent) error {
+		return encoder.Encode(event)
+	})
+}
+
+// parseSSE reads server-sent events as described by the HTML specification,
+// calling emit as each event ends with a blank line. Comments and the retry
+// field are ignored.
+func parseSSE(r io.Reader, emit func(sseEvent) error) error {
+	reader := bufio.NewReader(r)
+
+	event := sseEvent{}
+	data := []string{}
+	hasData := false
+
+	dispatch := func() error {
+		defer func() {
+			event = sseEvent{}
+			data = data[:0]
+			hasData = false
+		}()
+
+		if !hasData {
+			return nil
+		}
+		if len(event.Event) == 0 {
+			event.Event = "message"
+		}
+		event.Data = strings.Join(data, "\n")
+		return emit(event)
+	}
+
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) == 0 && err != nil {
+			if err == io.EOF {
+				return dispatch()
+			}
+			return err
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		if len(line) == 0 {
+			if err := dispatch(); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "event":
+			event.Event = value
+		case "data":
+			data = append(data, value)
+			hasData = true
+		case "id":
+			event.ID = value
+		}
+
+		if err == io.EOF {
+			return dispatch()
+		}
+	}
+}
+
+// isEventStream reports whether the response holds server-sent events
+func isEventStream(res *http.Response) bool {
+	mediaType, _, _ := mime.ParseMediaType(res.Header.Get("Content-Type"))
+	return mediaType == eventStreamContentType
+}
@@ -0,0 +1,128 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openfaas/faas-cli/test"
+)
+
+func Test_parseSSE(t *testing.T) {
+	in := ": keep-alive\n" +
+		"data: first\n\n" +
+		"event: token\r\nid: 2\r\ndata: line one\r\ndata: line two\r\n\r\n" +
+		"event: ignored-without-data\n\n" +
+		"retry: 1000\n" +
+		"data:no space\n" +
+		"data"
+
+	events := []sseEvent{}
+	err := parseSSE(strings.NewReader(in), func(event sseEvent) error {
+		events = append(events, event)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []sseEvent{
+		{Event: "message", Data: "first"},
+		{Event: "token", ID: "2", Data: "line one\nline two"},
+		{Event: "message", Data: "no space\n"},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Fatalf("want %+v, got: %+v", want, events)
+	}
+}
+
+// newStreamingFunction returns a function which sends each event after a
+// delay, taking longer in total than the command timeout.
+func newStreamingFunction(t *testing.T, delay time.Duration, events ...string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != eventStreamContentType {
+			t.Errorf("want Accept: %s, got: %q", eventStreamContentType, r.Header.Get("Accept"))
+		}
+
+		w.Header().Set("Content-Type", eventStreamContentType+"; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		for _, event := range events {
+			time.Sleep(delay)
+			fmt.Fprintf(w, "data: %s\n\n", event)
+			w.(http.Flusher).Flush()
+		}
+	}))
+}
+
+func Test_invoke_Stream(t *testing.T) {
+	resetForTest()
+	defaultTimeout := commandTimeout
+	defer func() {
+		commandTimeout = defaultTimeout
+		invokeStream = false
+		invokeOutput = ""
+	}()
+	commandTimeout = 50 * time.Millisecond
+
+	s := newStreamingFunction(t, 30*time.Millisecond, "one", "two", "three")
+	defer s.Close()
+
+	os.Stdin, _ = os.CreateTemp("", "stdin")
+	defer os.Remove(os.Stdin.Name())
+
+	cases := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{name: "raw", output: "", want: "data: one\n\ndata: two\n\ndata: three\n\n"},
+		{name: "jsonl", output: "jsonl", want: `{"event":"message","data":"one"}
+{"event":"message","data":"two"}
+{"event":"message","data":"three"}
+`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var err error
+			stdOut := test.CaptureStdout(func() {
+				faasCmd.SetArgs([]string{
+					"invoke",
+					"--gateway=" + s.URL,
+					"--stream",
+					"--output=" + tc.output,
+					"chat",
+				})
+				err = faasCmd.Execute()
+			})
+
+			if err != nil {
+				t.Fatalf("want no error, got: %s", err)
+			}
+			if stdOut != tc.want {
+				t.Fatalf("want %q, got: %q", tc.want, stdOut)
+			}
+		})
+	}
+}
+
+func Test_invoke_Stream_WithAsync(t *testing.T) {
+	resetForTest()
+	defer func() {
+		invokeStream = false
+		invokeAsync = false
+	}()
+
+	faasCmd.SetArgs([]string{"invoke", "--stream", "--async", "chat"})
+	if err := faasCmd.Execute(); err == nil {
+		t.Fatalf("want an error for --stream with --async")
+	}
+}
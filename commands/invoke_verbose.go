@@ -0,0 +1,198 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openfaas/faas-cli/har"
+)
+
+var invokeVerbose bool
+
+// invokeTrace records the headers written and the time taken by each phase
+// of a request. It is reset when a connection is requested, so that after the
+// IAM retry of invokeFunction it describes the second request.
+type invokeTrace struct {
+	mu sync.Mutex
+
+	header http.Header
+
+	start                    time.Time
+	dnsStart, dnsDone        time.Time
+	connectStart, connectEnd time.Time
+	tlsStart, tlsDone        time.Time
+	firstByte                time.Time
+}
+
+// invokeTimings are given in milliseconds, a phase which did not happen,
+// such as DNS for a reused connection, is omitted.
+type invokeTimings struct {
+	DNS     *float64 `json:"dnsMs,omitempty"`
+	Connect *float64 `json:"connectMs,omitempty"`
+	TLS     *float64 `json:"tlsMs,omitempty"`
+	TTFB    *float64 `json:"ttfbMs,omitempty"`
+	Total   float64  `json:"totalMs"`
+}
+
+// invokeEnvelope is printed by --output json
+type invokeEnvelope struct {
+	Status       int         `json:"status"`
+	Headers      http.Header `json:"headers"`
+	Body         string      `json:"body"`
+	BodyEncoding string      `json:"bodyEncoding,omitempty"`
+	DurationMs   float64     `json:"durationMs"`
+}
+
+func (t *invokeTrace) clientTrace() *httptrace.ClientTrace {
+	set := func(field *time.Time) {
+		t.mu.Lock()
+		*field = time.Now()
+		t.mu.Unlock()
+	}
+
+	return &httptrace.ClientTrace{
+		GetConn:           func(string) { t.reset() },
+		DNSStart:          func(httptrace.DNSStartInfo) { set(&t.dnsStart) },
+		DNSDone:           func(httptrace.DNSDoneInfo) { set(&t.dnsDone) },
+		ConnectStart:      func(string, string) { set(&t.connectStart) },
+		ConnectDone:       func(string, string, error) { set(&t.connectEnd) },
+		TLSHandshakeStart: func() { set(&t.tlsStart) },
+		TLSHandshakeDone:  func(tls.ConnectionState, error) { set(&t.tlsDone) },
+		WroteHeaderField: func(key string, value []string) {
+			t.mu.Lock()
+			if t.header == nil {
+				t.header = http.Header{}
+			}
+			for _, v := range value {
+				t.header.Add(key, v)
+			}
+			t.mu.Unlock()
+		},
+		GotFirstResponseByte: func() { set(&t.firstByte) },
+	}
+}
+
+func (t *invokeTrace) reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.header = http.Header{}
+	t.start = time.Now()
+	t.dnsStart, t.dnsDone = time.Time{}, time.Time{}
+	t.connectStart, t.connectEnd = time.Time{}, time.Time{}
+	t.tlsStart, t.tlsDone = time.Time{}, time.Time{}
+	t.firstByte = time.Time{}
+}
+
+// timings returns the phases of the last request, with the total measured
+// until end.
+func (t *invokeTrace) timings(end time.Time) invokeTimings {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	span := func(from, to time.Time) *float64 {
+		if from.IsZero() || to.IsZero() {
+			return nil
+		}
+		ms := milliseconds(to.Sub(from))
+		return &ms
+	}
+
+	timings := invokeTimings{
+		DNS:     span(t.dnsStart, t.dnsDone),
+		Connect: span(t.connectStart, t.connectEnd),
+		TLS:     span(t.tlsStart, t.tlsDone),
+		TTFB:    span(t.start, t.firstByte),
+	}
+	if !t.start.IsZero() {
+		timings.Total = milliseconds(end.Sub(t.start))
+	}
+	return timings
+}
+
+// printVerboseRequest prints the request line and the headers as they were
+// written, in the style of curl -v.
+func printVerboseRequest(w io.Writer, req *http.Request, trace *invokeTrace) {
+	trace.mu.Lock()
+	header := trace.header.Clone()
+	trace.mu.Unlock()
+
+	fmt.Fprintf(w, "> %s %s %s\n", req.Method, req.URL.RequestURI(), req.Proto)
+	for _, line := range headerLines(header) {
+		fmt.Fprintf(w, "> %s\n", line)
+	}
+	fmt.Fprintln(w, ">")
+}
+
+func printVerboseResponse(w io.Writer, res *http.Response) {
+	fmt.Fprintf(w, "< %s %s\n", res.Proto, res.Status)
+	for _, line := range headerLines(res.Header) {
+		fmt.Fprintf(w, "< %s\n", line)
+	}
+	fmt.Fprintln(w, "<")
+}
+
+func printVerboseTimings(w io.Writer, timings invokeTimings) {
+	phase := func(name string, ms *float64) string {
+		if ms == nil {
+			return name + ": -"
+		}
+		return fmt.Sprintf("%s: %.2fms", name, *ms)
+	}
+
+	fmt.Fprintf(w, "* %s, %s, %s, %s, Total: %.2fms\n",
+		phase("DNS", timings.DNS),
+		phase("Connect", timings.Connect),
+		phase("TLS", timings.TLS),
+		phase("TTFB", timings.TTFB),
+		timings.Total)
+}
+
+// headerLines returns the headers sorted by name, with the value of
+// Authorization redacted.
+func headerLines(header http.Header) []string {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := []string{}
+	for _, name := range names {
+		for _, value := range header[name] {
+			if strings.EqualFold(name, "Authorization") {
+				value = har.RedactAuthorization(value)
+			}
+			lines = append(lines, name+": "+value)
+		}
+	}
+	return lines
+}
+
+func printInvokeEnvelope(w io.Writer, res *http.Response, body []byte, duration time.Duration) error {
+	envelope := invokeEnvelope{
+		Status:     res.StatusCode,
+		Headers:    res.Header,
+		DurationMs: milliseconds(duration),
+	}
+	envelope.Body, envelope.BodyEncoding = encodeBody(body)
+
+	data, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, string(data))
+	return nil
+}
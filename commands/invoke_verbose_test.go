@@ -0,0 +1,125 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openfaas/faas-cli/test"
+)
+
+func Test_invokeTrace_Verbose(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Duration-Seconds", "0.001")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("failed"))
+	}))
+	defer s.Close()
+
+	trace := &invokeTrace{}
+	ctx := httptrace.WithClientTrace(context.Background(), trace.clientTrace())
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, s.URL+"/function/env?x=1", strings.NewReader("hi"))
+	req.Header.Set("Authorization", "Bearer secret-token")
+	req.Header.Set("Content-Type", "text/plain")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+
+	var b bytes.Buffer
+	printVerboseRequest(&b, res.Request, trace)
+	printVerboseResponse(&b, res)
+	printVerboseTimings(&b, trace.timings(time.Now()))
+	out := b.String()
+
+	for _, want := range []string{
+		"> POST /function/env?x=1 HTTP/1.1\n",
+		"> Authorization: Bearer [REDACTED]\n",
+		"> Content-Type: text/plain\n",
+		"< HTTP/1.1 500 Internal Server Error\n",
+		"< X-Duration-Seconds: 0.001\n",
+		"TTFB: ",
+		"Total: ",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("want %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "secret-token") {
+		t.Errorf("want the token redacted, got:\n%s", out)
+	}
+}
+
+func Test_invoke_OutputJSON(t *testing.T) {
+	resetForTest()
+	defer func() { invokeOutput = "" }()
+
+	cases := []struct {
+		name         string
+		status       int
+		body         []byte
+		wantBody     string
+		wantEncoding string
+	}{
+		{name: "text", status: http.StatusOK, body: []byte("hello"), wantBody: "hello"},
+		{name: "error status", status: http.StatusInternalServerError, body: []byte("failed"), wantBody: "failed"},
+		{name: "binary", status: http.StatusOK, body: []byte{0xff, 0xd8, 0xff}, wantBody: base64.StdEncoding.EncodeToString([]byte{0xff, 0xd8, 0xff}), wantEncoding: "base64"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("X-Test", "1")
+				w.WriteHeader(tc.status)
+				w.Write(tc.body)
+			}))
+			defer s.Close()
+
+			os.Stdin, _ = os.CreateTemp("", "stdin")
+			defer os.Remove(os.Stdin.Name())
+
+			var err error
+			stdOut := test.CaptureStdout(func() {
+				faasCmd.SetArgs([]string{
+					"invoke",
+					"--gateway=" + s.URL,
+					"-o", "json",
+					"env",
+				})
+				err = faasCmd.Execute()
+			})
+			if err != nil {
+				t.Fatalf("want no error, got: %s", err)
+			}
+
+			envelope := invokeEnvelope{}
+			if err := json.Unmarshal([]byte(stdOut), &envelope); err != nil {
+				t.Fatalf("want a JSON envelope, got: %q", stdOut)
+			}
+
+			if envelope.Status != tc.status || envelope.Headers.Get("X-Test") != "1" {
+				t.Fatalf("unexpected envelope: %+v", envelope)
+			}
+			if envelope.Body != tc.wantBody || envelope.BodyEncoding != tc.wantEncoding {
+				t.Fatalf("want body %q with encoding %q, got: %q with %q", tc.wantBody, tc.wantEncoding, envelope.Body, envelope.BodyEncoding)
+			}
+			if envelope.DurationMs <= 0 {
+				t.Fatalf("want a duration, got: %f", envelope.DurationMs)
+			}
+		})
+	}
+}
@@ -6,9 +6,11 @@ package commands
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"sort"
 
+	"github.com/openfaas/faas-cli/output"
 	"github.com/openfaas/faas-cli/proxy"
 	"github.com/openfaas/faas-provider/types"
 	"github.com/openfaas/go-sdk/stack"
@@ -19,6 +21,7 @@ var (
 	verboseList bool
 	token       string
 	sortOrder   string
+	listFormat  string
 )
 
 func init() {
@@ -32,6 +35,8 @@ func init() {
 	listCmd.Flags().BoolVar(&envsubst, "envsubst", true, "Substitute environment variables in stack.yaml file")
 	listCmd.Flags().StringVarP(&token, "token", "k", "", "Pass a JWT token to use instead of basic auth")
 	listCmd.Flags().StringVar(&sortOrder, "sort", "name", "Sort the functions by \"name\" or \"invocations\"")
+	listCmd.Flags().StringVar(&listFormat, "format", "", "Print each function with a Go template, i.e. '{{.Name}} {{.Image | trunc 40}}'")
+	listCmd.Flags().StringVarP(&outputFormat, "output", "o", "", output.FlagUsage)
 
 	faasCmd.AddCommand(listCmd)
 }
@@ -42,11 +47,30 @@ var listCmd = &cobra.Command{
 	Short:   "List OpenFaaS functions",
 	Long:    `Lists OpenFaaS functions either on a local or remote gateway`,
 	Example: `  faas-cli list
-  faas-cli list --gateway https://127.0.0.1:8080 --verbose`,
+  faas-cli list --gateway https://127.0.0.1:8080 --verbose
+  faas-cli list -o json
+  faas-cli list -o go-template='{{.Name}} {{.Image}}'
+  faas-cli list --format '{{.Name}}.{{.Namespace}} {{.Replicas}} {{json .Labels}}'`,
 	RunE: runList,
 }
 
 func runList(cmd *cobra.Command, args []string) error {
+	if (quiet || verboseList) && (len(outputFormat) > 0 || len(listFormat) > 0) {
+		return fmt.Errorf("--quiet and --verbose cannot be used with --output or --format")
+	}
+
+	outputValue := outputFormat
+	if quiet {
+		outputValue = string(output.NameFormat)
+	} else if verboseList {
+		outputValue = string(output.WideFormat)
+	}
+
+	printer, err := newOutputPrinter(outputValue, listFormat)
+	if err != nil {
+		return err
+	}
+
 	var services stack.Services
 	var gatewayAddress string
 	var yamlGateway string
@@ -77,6 +101,9 @@ func runList(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if functions == nil {
+		functions = []types.FunctionStatus{}
+	}
 
 	if sortOrder == "name" {
 		sort.Sort(byName(functions))
@@ -86,12 +113,22 @@ func runList(cmd *cobra.Command, args []string) error {
 		sort.Sort(byCreation(functions))
 	}
 
-	if quiet {
-		for _, function := range functions {
-			fmt.Printf("%s\n", function.Name)
-		}
-	} else if verboseList {
+	names := make([]string, 0, len(functions))
+	for _, function := range functions {
+		names = append(names, function.Name)
+	}
 
+	return printer.Print(os.Stdout, output.Result{
+		Object: functions,
+		Names:  names,
+		Table: func(w io.Writer, wide bool) {
+			printFunctionList(w, functions, wide)
+		},
+	})
+}
+
+func printFunctionList(w io.Writer, functions []types.FunctionStatus, wide bool) {
+	if wide {
 		maxWidth := 40
 		for _, function := range functions {
 			if len(function.Image) > maxWidth {
@@ -99,21 +136,17 @@ func runList(cmd *cobra.Command, args []string) error {
 			}
 		}
 
-		fmt.Printf("%-30s\t%-"+fmt.Sprintf("%d", maxWidth)+"s\t%-15s\t%-5s\t%-5s\n", "Function", "Image", "Invocations", "Replicas", "CreatedAt")
-		for _, function := range functions {
-			functionImage := function.Image
-			// if len(function.Image) > 40 {
-			// 	functionImage = functionImage[0:38] + ".."
-			// }
-			fmt.Printf("%-30s\t%-"+fmt.Sprintf("%d", maxWidth)+"s\t%-15d\t%-5d\t\t%-5s\n", function.Name, functionImage, int64(function.InvocationCount), function.Replicas, function.CreatedAt.String())
-		}
-	} else {
-		fmt.Printf("%-30s\t%-15s\t%-5s\n", "Function", "Invocations", "Replicas")
+		fmt.Fprintf(w, "%-30s\t%-"+fmt.Sprintf("%d", maxWidth)+"s\t%-15s\t%-5s\t%-5s\n", "Function", "Image", "Invocations", "Replicas", "CreatedAt")
 		for _, function := range functions {
-			fmt.Printf("%-30s\t%-15d\t%-5d\n", function.Name, int64(function.InvocationCount), function.Replicas)
+			fmt.Fprintf(w, "%-30s\t%-"+fmt.Sprintf("%d", maxWidth)+"s\t%-15d\t%-5d\t\t%-5s\n", function.Name, function.Image, int64(function.InvocationCount), function.Replicas, function.CreatedAt.String())
 		}
+		return
+	}
+
+	fmt.Fprintf(w, "%-30s\t%-15s\t%-5s\n", "Function", "Invocations", "Replicas")
+	for _, function := range functions {
+		fmt.Fprintf(w, "%-30s\t%-15d\t%-5d\n", function.Name, int64(function.InvocationCount), function.Replicas)
 	}
-	return nil
 }
 
 type byName []types.FunctionStatus
@@ -78,3 +78,143 @@ func Test_list_errors(t *testing.T) {
 		t.Fatal("No error found while testing missing yaml")
 	}
 }
+
+func Test_list_Format(t *testing.T) {
+	s := test.MockHttpServer(t, []test.Request{
+		{
+			Method:             http.MethodGet,
+			Uri:                "/system/functions",
+			ResponseStatusCode: http.StatusOK,
+			ResponseBody: []types.FunctionStatus{
+				{Name: "function-test-2", Namespace: "openfaas-fn", Replicas: 3},
+				{Name: "function-test-1", Namespace: "openfaas-fn", Replicas: 1},
+			},
+		},
+	})
+	defer s.Close()
+
+	resetForTest()
+	defer func() { listFormat = "" }()
+
+	stdOut := test.CaptureStdout(func() {
+		faasCmd.SetArgs([]string{
+			"list",
+			"--gateway=" + s.URL,
+			"--format={{.Name}}.{{.Namespace}} {{.Replicas}}",
+		})
+		if err := faasCmd.Execute(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	want := "function-test-1.openfaas-fn 1\nfunction-test-2.openfaas-fn 3\n"
+	if stdOut != want {
+		t.Fatalf("want %q, got: %q", want, stdOut)
+	}
+}
+
+func Test_list_Output(t *testing.T) {
+	functions := []types.FunctionStatus{
+		{Name: "function-test-1", Image: "image-test-1", Replicas: 1},
+		{Name: "function-test-2", Image: "image-test-2", Replicas: 3},
+	}
+
+	cases := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "name", args: []string{"-o", "name"}, want: "function-test-1\nfunction-test-2\n"},
+		{name: "quiet", args: []string{"--quiet"}, want: "function-test-1\nfunction-test-2\n"},
+		{name: "go-template", args: []string{"-o", "go-template={{.Name}}:{{.Image}}"}, want: "function-test-1:image-test-1\nfunction-test-2:image-test-2\n"},
+		{name: "json", args: []string{"-o", "json"}, want: `[
+  {
+    "name": "function-test-1",
+    "image": "image-test-1",
+    "replicas": 1,
+    "createdAt": "0001-01-01T00:00:00Z"
+  },
+  {
+    "name": "function-test-2",
+    "image": "image-test-2",
+    "replicas": 3,
+    "createdAt": "0001-01-01T00:00:00Z"
+  }
+]
+`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := test.MockHttpServer(t, []test.Request{
+				{
+					Method:             http.MethodGet,
+					Uri:                "/system/functions",
+					ResponseStatusCode: http.StatusOK,
+					ResponseBody:       functions,
+				},
+			})
+			defer s.Close()
+
+			resetForTest()
+			defer func() {
+				outputFormat = ""
+				quiet = false
+			}()
+
+			var err error
+			stdOut := test.CaptureStdout(func() {
+				faasCmd.SetArgs(append([]string{"list", "--gateway=" + s.URL}, tc.args...))
+				err = faasCmd.Execute()
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stdOut != tc.want {
+				t.Fatalf("want %q, got: %q", tc.want, stdOut)
+			}
+		})
+	}
+}
+
+func Test_list_OutputEmptyJSON(t *testing.T) {
+	s := test.MockHttpServer(t, []test.Request{
+		{
+			Method:             http.MethodGet,
+			Uri:                "/system/functions",
+			ResponseStatusCode: http.StatusOK,
+			ResponseBody:       []types.FunctionStatus(nil),
+		},
+	})
+	defer s.Close()
+
+	resetForTest()
+	defer func() {
+		outputFormat = ""
+	}()
+
+	var err error
+	stdOut := test.CaptureStdout(func() {
+		faasCmd.SetArgs([]string{"list", "--gateway=" + s.URL, "-o", "json"})
+		err = faasCmd.Execute()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stdOut != "[]\n" {
+		t.Fatalf("want an empty JSON array, got: %q", stdOut)
+	}
+}
+
+func Test_list_OutputWithQuiet(t *testing.T) {
+	resetForTest()
+	defer func() {
+		outputFormat = ""
+		quiet = false
+	}()
+
+	faasCmd.SetArgs([]string{"list", "--quiet", "-o", "json"})
+	if err := faasCmd.Execute(); err == nil {
+		t.Fatalf("want an error for --quiet with --output")
+	}
+}
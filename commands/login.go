@@ -31,6 +31,18 @@ func init() {
 	loginCmd.Flags().BoolVar(&tlsInsecure, "tls-no-verify", false, "Disable TLS validation")
 	loginCmd.Flags().Duration("timeout", time.Second*5, "Override the timeout for this API call")
 
+	loginCmd.Flags().StringVar(&oidcIssuer, "oidc-issuer", "", "URL of an OIDC issuer to log in with instead of a username and password")
+	loginCmd.Flags().StringVar(&oidcClientID, "client-id", "", "OIDC client ID registered with the issuer")
+	loginCmd.Flags().StringSliceVar(&oidcScopes, "scope", []string{"openid", "profile", "email", "offline_access"}, "OIDC scopes to request")
+	loginCmd.Flags().StringVar(&oidcGrantType, "grant-type", oidcGrantCode, `OIDC grant to use, "code" opens a browser, "device" prints a code to enter on any device`)
+	loginCmd.Flags().IntVar(&oidcListenPort, "listen-port", 31111, "Port on 127.0.0.1 for the browser login callback, 0 picks a free port")
+	loginCmd.Flags().BoolVar(&oidcNoBrowser, "no-browser", false, "Print the login URL instead of opening a browser")
+
+	loginCmd.Flags().StringVar(&clientSecretFile, "client-secret-file", "", "Log in with the client credentials grant, reading the client secret from this file")
+	loginCmd.Flags().StringVar(&idTokenFile, "id-token-file", "", "Log in by exchanging the ID token in this file, i.e. from a CI job, for an OpenFaaS token")
+	loginCmd.Flags().StringVar(&tokenURL, "token-url", "", "Token endpoint for --client-secret-file or --id-token-file, defaults to the issuer's or the gateway's /oauth/token")
+	loginCmd.Flags().StringVar(&audience, "audience", "", "Audience to request for --client-secret-file or --id-token-file")
+
 	faasCmd.AddCommand(loginCmd)
 }
 
@@ -40,7 +52,11 @@ var loginCmd = &cobra.Command{
 	Long:  "Log in to OpenFaaS gateway.\nIf no gateway is specified, the default value will be used.",
 	Example: `  cat ~/faas_pass.txt | faas-cli login -u user --password-stdin
   echo $PASSWORD | faas-cli login -s  --gateway https://openfaas.mydomain.com
-  faas-cli login -u user -p password`,
+  faas-cli login -u user -p password
+  faas-cli login --oidc-issuer https://keycloak.example.com/realms/openfaas --client-id faas-cli
+  faas-cli login --oidc-issuer https://keycloak.example.com/realms/openfaas --client-id faas-cli --grant-type device
+  faas-cli login --client-id ci --client-secret-file ./client-secret.txt --token-url https://keycloak.example.com/realms/openfaas/protocol/openid-connect/token
+  faas-cli login --id-token-file $ACTIONS_ID_TOKEN_FILE --token-url https://gateway.example.com/oauth/token`,
 	RunE: runLogin,
 }
 
@@ -51,6 +67,14 @@ func runLogin(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if len(clientSecretFile) > 0 || len(idTokenFile) > 0 {
+		return runMachineLogin(cmd, timeout)
+	}
+
+	if len(oidcIssuer) > 0 {
+		return runOIDCLogin(timeout)
+	}
+
 	if len(username) == 0 {
 		return fmt.Errorf("must provide --username or -u")
 	}
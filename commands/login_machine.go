@@ -0,0 +1,96 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/openfaas/faas-cli/config"
+	"github.com/openfaas/faas-cli/oidc"
+	"github.com/openfaas/faas-cli/proxy"
+	"github.com/spf13/cobra"
+)
+
+var (
+	clientSecretFile string
+	idTokenFile      string
+	tokenURL         string
+	audience         string
+)
+
+// runMachineLogin saves how to mint tokens with the client credentials grant
+// or a token exchange, after checking that a token can be minted.
+func runMachineLogin(cmd *cobra.Command, timeout time.Duration) error {
+	if len(clientSecretFile) > 0 && len(idTokenFile) > 0 {
+		return fmt.Errorf("--client-secret-file and --id-token-file are mutually exclusive")
+	}
+
+	if len(clientSecretFile) > 0 && len(oidcClientID) == 0 {
+		return fmt.Errorf("must provide --client-id with --client-secret-file")
+	}
+
+	gateway = getGatewayURL(gateway, defaultGateway, "", os.Getenv(openFaaSURLEnvironment))
+
+	scope := ""
+	if cmd.Flags().Changed("scope") {
+		scope = strings.Join(oidcScopes, " ")
+	}
+
+	endpoint := tokenURL
+	if len(endpoint) == 0 {
+		switch {
+		case len(oidcIssuer) > 0:
+			client := proxy.MakeHTTPClient(&timeout, tlsInsecure)
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			provider, err := oidc.Discover(ctx, &client, oidcIssuer)
+			if err != nil {
+				return err
+			}
+			endpoint = provider.TokenEndpoint
+		case len(idTokenFile) > 0:
+			endpoint = gateway + "/oauth/token"
+		default:
+			return fmt.Errorf("must provide --token-url or --oidc-issuer with --client-secret-file")
+		}
+	}
+
+	var authConfig config.AuthConfig
+	if len(clientSecretFile) > 0 {
+		secretPath, err := filepath.Abs(clientSecretFile)
+		if err != nil {
+			return err
+		}
+		authConfig = oidc.NewClientCredentialsConfig(gateway, oidcClientID, secretPath, endpoint, scope, audience)
+	} else {
+		tokenPath, err := filepath.Abs(idTokenFile)
+		if err != nil {
+			return err
+		}
+		authConfig = oidc.NewTokenExchangeConfig(gateway, tokenPath, endpoint, scope, audience)
+	}
+
+	ts, err := oidc.NewTokenSource(authConfig)
+	if err != nil {
+		return err
+	}
+
+	if _, err := ts.Token(); err != nil {
+		return fmt.Errorf("unable to login: %w", err)
+	}
+
+	if err := config.UpdateAuthConfig(authConfig); err != nil {
+		return err
+	}
+
+	fmt.Println("credentials saved for", gateway)
+
+	return nil
+}
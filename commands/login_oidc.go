@@ -0,0 +1,78 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/openfaas/faas-cli/config"
+	"github.com/openfaas/faas-cli/oidc"
+	"github.com/openfaas/faas-cli/proxy"
+)
+
+const (
+	oidcGrantCode   = "code"
+	oidcGrantDevice = "device"
+
+	// oidcLoginTimeout is how long to wait for the user to complete a login
+	oidcLoginTimeout = 5 * time.Minute
+)
+
+var (
+	oidcIssuer     string
+	oidcClientID   string
+	oidcScopes     []string
+	oidcGrantType  string
+	oidcListenPort int
+	oidcNoBrowser  bool
+)
+
+// runOIDCLogin logs in with an OIDC issuer and saves the access and refresh
+// tokens for the gateway.
+func runOIDCLogin(timeout time.Duration) error {
+	if len(oidcClientID) == 0 {
+		return fmt.Errorf("must provide --client-id with --oidc-issuer")
+	}
+
+	gateway = getGatewayURL(gateway, defaultGateway, "", os.Getenv(openFaaSURLEnvironment))
+
+	ctx, cancel := context.WithTimeout(context.Background(), oidcLoginTimeout)
+	defer cancel()
+
+	client := proxy.MakeHTTPClient(&timeout, tlsInsecure)
+
+	provider, err := oidc.Discover(ctx, &client, oidcIssuer)
+	if err != nil {
+		return err
+	}
+
+	var token *oidc.Token
+	switch oidcGrantType {
+	case oidcGrantCode:
+		openBrowser := oidc.OpenBrowser
+		if oidcNoBrowser {
+			openBrowser = func(string) error { return fmt.Errorf("browser disabled") }
+		}
+		token, err = oidc.BrowserLogin(ctx, &client, provider, oidcClientID, oidcScopes, oidcListenPort, openBrowser, os.Stdout)
+	case oidcGrantDevice:
+		token, err = oidc.DeviceLogin(ctx, &client, provider, oidcClientID, oidcScopes, os.Stdout)
+	default:
+		return fmt.Errorf(`unknown --grant-type %q, use "code" or "device"`, oidcGrantType)
+	}
+	if err != nil {
+		return fmt.Errorf("unable to login: %w", err)
+	}
+
+	authConfig := oidc.NewAuthConfig(gateway, oidcIssuer, oidcClientID, provider, token)
+	if err := config.UpdateAuthConfig(authConfig); err != nil {
+		return err
+	}
+
+	fmt.Println("credentials saved for", gateway)
+
+	return nil
+}
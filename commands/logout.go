@@ -12,21 +12,29 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var logoutAll bool
+
 func init() {
 	logoutCmd.Flags().StringVarP(&gateway, "gateway", "g", defaultGateway, "Gateway URL starting with http(s)://")
+	logoutCmd.Flags().BoolVar(&logoutAll, "all", false, "Log out from every gateway with saved credentials")
 
 	faasCmd.AddCommand(logoutCmd)
 }
 
 var logoutCmd = &cobra.Command{
-	Use:     `logout [--gateway GATEWAY_URL]`,
-	Short:   "Log out from OpenFaaS gateway",
-	Long:    "Log out from OpenFaaS gateway.\nIf no gateway is specified, the default local one will be used.",
-	Example: `  faas-cli logout --gateway https://openfaas.mydomain.com`,
-	RunE:    runLogout,
+	Use:   `logout [--gateway GATEWAY_URL] [--all]`,
+	Short: "Log out from OpenFaaS gateway",
+	Long:  "Log out from OpenFaaS gateway.\nIf no gateway is specified, the default local one will be used.",
+	Example: `  faas-cli logout --gateway https://openfaas.mydomain.com
+  faas-cli logout --all`,
+	RunE: runLogout,
 }
 
 func runLogout(cmd *cobra.Command, args []string) error {
+	if logoutAll {
+		return logoutFromAll()
+	}
+
 	if len(gateway) == 0 {
 		return fmt.Errorf("gateway cannot be an empty string")
 	}
@@ -41,3 +49,24 @@ func runLogout(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func logoutFromAll() error {
+	authConfigs, err := config.ListAuthConfigs()
+	if err != nil {
+		return err
+	}
+
+	if len(authConfigs) == 0 {
+		fmt.Println("No saved credentials found.")
+		return nil
+	}
+
+	for _, authConfig := range authConfigs {
+		if err := config.RemoveAuthConfig(authConfig.Gateway); err != nil {
+			return err
+		}
+		fmt.Println("credentials removed for", authConfig.Gateway)
+	}
+
+	return nil
+}
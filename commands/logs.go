@@ -10,10 +10,13 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/openfaas/faas-cli/flags"
 	"github.com/openfaas/faas-provider/logs"
+	"github.com/openfaas/go-sdk/stack"
 
 	"github.com/openfaas/faas-cli/proxy"
 	"github.com/spf13/cobra"
@@ -35,6 +38,13 @@ type logFlags struct {
 	includeName     bool
 	includeInstance bool
 	timeFormat      flags.TimeFormat
+	all             bool
+	grep            string
+	exclude         string
+	level           string
+	fields          []string
+	followForever   bool
+	format          string
 }
 
 func init() {
@@ -44,25 +54,40 @@ func init() {
 }
 
 var functionLogsCmd = &cobra.Command{
-	Use:   `logs <NAME> [--tls-no-verify] [--gateway] [--output=text/json]`,
+	Use: `logs <NAME> [<NAME>...] [--tls-no-verify] [--gateway] [--output=text/json]
+  faas-cli logs -f YAML_FILE [--regex "REGEX"] [--filter "WILDCARD"]
+  faas-cli logs --all [--namespace NAMESPACE]`,
 	Short: "Fetch logs for a functions",
-	Long:  "Fetch logs for a given function name in plain text or JSON format.",
+	Long: `Fetch logs for one or more functions in plain text or JSON format.
+
+When more than one function is given, by name, from a stack file or with
+--all, the logs are streamed together and ordered by timestamp. In plain mode
+each line starts with the name of its function, and the name is always
+included by --output=keyvalue and --output=json.
+
+Lines written as a JSON object or as logfmt can be filtered by --level and
+reduced to a few --fields. With --output=json, such a line is nested as an
+object in the "text" field rather than as an escaped string.
+
+--format renders each message with a Go template, given the fields Name,
+Namespace, Instance, Timestamp and Text. The functions color, trunc and json
+are available, as is parse, which returns the fields of a JSON or logfmt line.`,
 	Example: `  faas-cli logs FN
+  faas-cli logs FN1 FN2 FN3
+  faas-cli logs -f stack.yaml --filter "*-api"
+  faas-cli logs --all --namespace openfaas-fn
+  faas-cli logs FN --grep "timeout" --exclude "healthz"
+  faas-cli logs FN --level warn --fields level,msg
+  faas-cli logs FN --format '{{.Timestamp.Format "15:04:05"}} {{.Instance | trunc 8}} {{.Text}}'
+  faas-cli logs FN --format '{{with parse .Text}}{{.level | color "yellow"}} {{.msg}}{{end}}'
   faas-cli logs FN --output=json
   faas-cli logs FN --lines=5
   faas-cli logs FN --tail=false --since=10m
+  faas-cli logs FN --follow-forever
   faas-cli logs FN --tail=false --since=2010-01-01T00:00:00Z
 `,
-	Args:    cobra.MaximumNArgs(1),
 	RunE:    runLogs,
-	PreRunE: noopPreRunCmd,
-}
-
-func noopPreRunCmd(cmd *cobra.Command, args []string) error {
-	if len(args) == 0 {
-		return fmt.Errorf("function name is required")
-	}
-	return nil
+	PreRunE: validateLogsArgs,
 }
 
 // initLogCmdFlags configures the logs command flags, this allows the developer to
@@ -78,7 +103,8 @@ func initLogCmdFlags(cmd *cobra.Command) {
 	cmd.Flags().DurationVar(&logFlagValues.since, "since", 0*time.Second, "return logs newer than a relative duration like 5s")
 	cmd.Flags().Var(&logFlagValues.sinceTime, "since-time", "include logs since the given timestamp (RFC3339)")
 	cmd.Flags().IntVar(&logFlagValues.lines, "lines", -1, "number of recent log lines file to display. Defaults to -1, unlimited if <=0")
-	cmd.Flags().BoolVarP(&logFlagValues.tail, "tail", "t", true, "tail logs and continue printing new logs until the end of the request, up to 30s")
+	cmd.Flags().BoolVarP(&logFlagValues.tail, "tail", "t", true, "tail logs and continue printing new logs until the end of the request, up to 30s, see --follow-forever")
+	cmd.Flags().BoolVar(&logFlagValues.followForever, "follow-forever", false, "reconnect whenever the log stream ends, and keep printing new logs until Ctrl+C")
 	cmd.Flags().StringVarP(&logFlagValues.token, "token", "k", "", "Pass a JWT token to use instead of basic auth")
 
 	logFlagValues.timeFormat = flags.TimeFormat(time.RFC3339)
@@ -86,11 +112,40 @@ func initLogCmdFlags(cmd *cobra.Command) {
 	cmd.Flags().Var(&logFlagValues.timeFormat, "time-format", "string format for the timestamp, any value go time format string is allowed, empty will not print the timestamp")
 	cmd.Flags().BoolVar(&logFlagValues.includeName, "name", false, "print the function name")
 	cmd.Flags().BoolVar(&logFlagValues.includeInstance, "instance", false, "print the function instance name/id")
+	cmd.Flags().BoolVar(&logFlagValues.all, "all", false, "stream the logs of every function in the namespace")
+	cmd.Flags().StringVar(&logFlagValues.grep, "grep", "", "only print lines which match the regular expression")
+	cmd.Flags().StringVar(&logFlagValues.exclude, "exclude", "", "leave out lines which match the regular expression")
+	cmd.Flags().StringVar(&logFlagValues.level, "level", "", "only print JSON or logfmt lines logged at this level or above, such as warn")
+	cmd.Flags().StringVar(&logFlagValues.format, "format", "", "render each message with a Go template, i.e. '{{.Timestamp.Format \"15:04:05\"}} {{.Instance | trunc 8}} {{.Text}}'")
+	cmd.Flags().StringSliceVar(&logFlagValues.fields, "fields", []string{}, "print only these fields of JSON or logfmt lines, i.e. --fields level,msg")
 }
 
 func runLogs(cmd *cobra.Command, args []string) error {
+	logFilter, err := newLogFilter(logFlagValues.grep, logFlagValues.exclude, logFlagValues.level, logFlagValues.fields,
+		logFlagValues.logFormat == flags.JSONLogFormat)
+	if err != nil {
+		return err
+	}
+
+	var targets []logTarget
+	var yamlGateway string
 
-	gatewayAddress := getGatewayURL(gateway, defaultGateway, "", os.Getenv(openFaaSURLEnvironment))
+	if len(args) == 0 && !logFlagValues.all && len(yamlFile) > 0 {
+		parsedServices, err := stack.ParseYAMLFile(yamlFile, regex, filter, envsubst)
+		if err != nil {
+			return err
+		}
+
+		if parsedServices != nil {
+			targets = logTargetsFromStack(*parsedServices)
+			yamlGateway = parsedServices.Provider.GatewayURL
+		}
+		if len(targets) == 0 {
+			return fmt.Errorf("no functions found in %s", yamlFile)
+		}
+	}
+
+	gatewayAddress := getGatewayURL(gateway, defaultGateway, yamlGateway, os.Getenv(openFaaSURLEnvironment))
 	if msg := checkTLSInsecure(gatewayAddress, tlsInsecure); len(msg) > 0 {
 		fmt.Println(msg)
 	}
@@ -106,14 +161,76 @@ func runLogs(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	logEvents, err := cliClient.GetLogs(context.Background(), logRequest)
+	ctx := context.Background()
+	open := logStreamOpener(cliClient.GetLogs)
+
+	if logFlagValues.followForever {
+		var cancel context.CancelFunc
+		ctx, cancel = signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		defer cancel()
+
+		open = followForever(cliClient.GetLogs, followForeverPolicy)
+	}
+
+	switch {
+	case len(args) > 0:
+		for _, name := range args {
+			targets = append(targets, logTarget{Name: name, Namespace: logRequest.Namespace})
+		}
+	case logFlagValues.all:
+		targets, err = listLogTargets(ctx, cliClient, logRequest.Namespace)
+		if err != nil {
+			return err
+		}
+		if len(targets) == 0 {
+			return fmt.Errorf("no functions found")
+		}
+	}
+
+	formatter := GetLogFormatter(string(logFlagValues.logFormat))
+	if len(logFlagValues.format) > 0 {
+		if formatter, err = NewTemplateLogFormatter(logFlagValues.format); err != nil {
+			return err
+		}
+	}
+	timeFormat := logFlagValues.timeFormat.String()
+
+	if len(targets) == 1 {
+		logRequest.Name, logRequest.Namespace = targets[0].Name, targets[0].Namespace
+
+		logEvents, err := open(ctx, logRequest)
+		if err != nil {
+			return err
+		}
+
+		for logMsg := range logEvents {
+			if logMsg, ok := logFilter.apply(logMsg); ok {
+				fmt.Fprintln(os.Stdout, formatter(logMsg, timeFormat, logFlagValues.includeName, logFlagValues.includeInstance))
+			}
+		}
+		return nil
+	}
+
+	streams, err := openLogStreams(ctx, open, logRequest, targets)
 	if err != nil {
 		return err
 	}
 
-	formatter := GetLogFormatter(string(logFlagValues.logFormat))
-	for logMsg := range logEvents {
-		fmt.Fprintln(os.Stdout, formatter(logMsg, logFlagValues.timeFormat.String(), logFlagValues.includeName, logFlagValues.includeInstance))
+	plain := logFlagValues.logFormat != flags.JSONLogFormat && logFlagValues.logFormat != flags.KeyValueLogFormat &&
+		len(logFlagValues.format) == 0
+	prefixer := newLogPrefixer(targets, isTerminal(os.Stdout))
+
+	for logMsg := range mergeLogStreams(streams, logMergeWindow) {
+		logMsg, ok := logFilter.apply(logMsg)
+		if !ok {
+			continue
+		}
+
+		if plain {
+			fmt.Fprintln(os.Stdout, prefixer.prefix(logMsg.Name)+formatter(logMsg, timeFormat, logFlagValues.includeName, logFlagValues.includeInstance))
+			continue
+		}
+		fmt.Fprintln(os.Stdout, formatter(logMsg, timeFormat, true, logFlagValues.includeInstance))
 	}
 
 	return nil
@@ -126,8 +243,13 @@ func logRequestFromFlags(cmd *cobra.Command, args []string) logs.Request {
 		log.Printf("error getting namespace flag %s\n", err.Error())
 	}
 
+	name := ""
+	if len(args) > 0 {
+		name = args[0]
+	}
+
 	return logs.Request{
-		Name:      args[0],
+		Name:      name,
 		Namespace: ns,
 		Tail:      logFlagValues.lines,
 		Since:     sinceValue(logFlagValues.sinceTime.AsTime(), logFlagValues.since),
@@ -154,7 +276,7 @@ func getLogStreamingTransport(tlsInsecure bool) http.RoundTripper {
 		}
 		tr.TLSClientConfig = &tls.Config{InsecureSkipVerify: tlsInsecure}
 
-		return tr
+		return withHARRecorder(tr)
 	}
-	return nil
+	return withHARRecorder(nil)
 }
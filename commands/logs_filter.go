@@ -0,0 +1,335 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openfaas/faas-provider/logs"
+)
+
+// logLevels ranks the level names found in structured logs, aliases map to
+// the same rank.
+var logLevels = map[string]int{
+	"trace":    0,
+	"debug":    1,
+	"info":     2,
+	"notice":   2,
+	"warn":     3,
+	"warning":  3,
+	"error":    4,
+	"err":      4,
+	"fatal":    5,
+	"panic":    5,
+	"critical": 5,
+	"crit":     5,
+}
+
+// logLevelKeys are the keys checked, in order, for the level of a structured
+// log line
+var logLevelKeys = []string{"level", "lvl", "severity", "loglevel", "log.level"}
+
+// logFilter decides which log messages are printed, and with --fields,
+// reduces a structured message to the fields requested.
+type logFilter struct {
+	grep    *regexp.Regexp
+	exclude *regexp.Regexp
+	level   int
+	fields  []string
+
+	// jsonFields keeps the fields of a JSON payload as JSON, so that their
+	// types survive into --output=json.
+	jsonFields bool
+}
+
+func newLogFilter(grep, exclude, level string, fields []string, jsonFields bool) (*logFilter, error) {
+	f := &logFilter{level: -1, jsonFields: jsonFields}
+
+	var err error
+	if len(grep) > 0 {
+		if f.grep, err = regexp.Compile(grep); err != nil {
+			return nil, fmt.Errorf("invalid --grep: %w", err)
+		}
+	}
+	if len(exclude) > 0 {
+		if f.exclude, err = regexp.Compile(exclude); err != nil {
+			return nil, fmt.Errorf("invalid --exclude: %w", err)
+		}
+	}
+
+	if len(level) > 0 {
+		rank, ok := logLevels[strings.ToLower(level)]
+		if !ok {
+			return nil, fmt.Errorf("unknown --level %q, use one of trace, debug, info, warn, error or fatal", level)
+		}
+		f.level = rank
+	}
+
+	for _, field := range fields {
+		if field = strings.TrimSpace(field); len(field) > 0 {
+			f.fields = append(f.fields, field)
+		}
+	}
+
+	return f, nil
+}
+
+// apply returns the message to print, or false when it is filtered out.
+// With --level, a message without a level it can detect is filtered out.
+func (f *logFilter) apply(msg logs.Message) (logs.Message, bool) {
+	text := strings.TrimRight(msg.Text, "\n")
+
+	if f.grep != nil && !f.grep.MatchString(text) {
+		return msg, false
+	}
+	if f.exclude != nil && f.exclude.MatchString(text) {
+		return msg, false
+	}
+
+	if f.level < 0 && len(f.fields) == 0 {
+		return msg, true
+	}
+
+	payload, format := parseLogPayload(text)
+	if payload == nil {
+		return msg, f.level < 0
+	}
+
+	if f.level >= 0 {
+		level, ok := payloadLevel(payload)
+		if !ok || level < f.level {
+			return msg, false
+		}
+	}
+
+	if len(f.fields) > 0 {
+		msg.Text = f.selectFields(payload, format == jsonPayload && f.jsonFields)
+	}
+	return msg, true
+}
+
+// selectFields renders the fields requested, in the order they were given,
+// leaving out any which are missing.
+func (f *logFilter) selectFields(payload map[string]interface{}, asJSON bool) string {
+	if asJSON {
+		var b bytes.Buffer
+		b.WriteString("{")
+		for _, field := range f.fields {
+			value, ok := lookupField(payload, field)
+			if !ok {
+				continue
+			}
+			if b.Len() > 1 {
+				b.WriteString(",")
+			}
+			key, _ := json.Marshal(field)
+			data, _ := json.Marshal(value)
+			b.Write(key)
+			b.WriteString(":")
+			b.Write(data)
+		}
+		b.WriteString("}")
+		return b.String()
+	}
+
+	pairs := []string{}
+	for _, field := range f.fields {
+		if value, ok := lookupField(payload, field); ok {
+			pairs = append(pairs, field+"="+logfmtValue(value))
+		}
+	}
+	return strings.Join(pairs, " ")
+}
+
+const (
+	jsonPayload   = "json"
+	logfmtPayload = "logfmt"
+)
+
+// parseLogPayload parses a log line written as a JSON object or as logfmt,
+// and returns nil for any other line.
+func parseLogPayload(text string) (map[string]interface{}, string) {
+	text = strings.TrimSpace(text)
+
+	if strings.HasPrefix(text, "{") {
+		decoder := json.NewDecoder(strings.NewReader(text))
+		decoder.UseNumber()
+
+		payload := map[string]interface{}{}
+		if err := decoder.Decode(&payload); err == nil && !decoder.More() {
+			return payload, jsonPayload
+		}
+		return nil, ""
+	}
+
+	if payload := parseLogfmt(text); payload != nil {
+		return payload, logfmtPayload
+	}
+	return nil, ""
+}
+
+// parseLogfmt parses key=value pairs separated by spaces, where a value may
+// be quoted. Any token which is not a pair means the line is not logfmt.
+func parseLogfmt(text string) map[string]interface{} {
+	payload := map[string]interface{}{}
+
+	for len(text) > 0 {
+		text = strings.TrimLeft(text, " \t")
+		if len(text) == 0 {
+			break
+		}
+
+		eq := strings.IndexAny(text, "= \t\"")
+		if eq <= 0 || text[eq] != '=' {
+			return nil
+		}
+		key := text[:eq]
+		text = text[eq+1:]
+
+		var value string
+		if strings.HasPrefix(text, `"`) {
+			end := quotedEnd(text)
+			if end < 0 {
+				return nil
+			}
+			unquoted, err := strconv.Unquote(text[:end+1])
+			if err != nil {
+				return nil
+			}
+			value, text = unquoted, text[end+1:]
+			if len(text) > 0 && text[0] != ' ' && text[0] != '\t' {
+				return nil
+			}
+		} else {
+			end := strings.IndexAny(text, " \t")
+			if end < 0 {
+				end = len(text)
+			}
+			value, text = text[:end], text[end:]
+			if strings.Contains(value, `"`) {
+				return nil
+			}
+		}
+
+		payload[key] = value
+	}
+
+	if len(payload) == 0 {
+		return nil
+	}
+	return payload
+}
+
+// quotedEnd returns the index of the quote which closes the string at the
+// start of text, or -1
+func quotedEnd(text string) int {
+	for i := 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// payloadLevel finds the level of a structured log line. Numeric levels are
+// read as used by pino and bunyan, where 30 is info and 40 is warn.
+func payloadLevel(payload map[string]interface{}) (int, bool) {
+	for _, key := range logLevelKeys {
+		value, ok := lookupField(payload, key)
+		if !ok {
+			continue
+		}
+
+		switch v := value.(type) {
+		case string:
+			if rank, ok := logLevels[strings.ToLower(v)]; ok {
+				return rank, true
+			}
+		case json.Number:
+			if n, err := v.Int64(); err == nil && n >= 10 {
+				return min(int(n/10)-1, logLevels["fatal"]), true
+			}
+		}
+	}
+	return 0, false
+}
+
+// lookupField returns the value of key, or when the key has dots and is not
+// found, the value found by following each part into nested objects.
+func lookupField(payload map[string]interface{}, key string) (interface{}, bool) {
+	if value, ok := payload[key]; ok {
+		return value, true
+	}
+
+	var current interface{} = payload
+	for _, part := range strings.Split(key, ".") {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = object[part]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func logfmtValue(value interface{}) string {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case json.Number:
+		s = v.String()
+	case nil:
+		s = "null"
+	case map[string]interface{}, []interface{}:
+		data, _ := json.Marshal(v)
+		s = string(data)
+	default:
+		s = fmt.Sprint(v)
+	}
+
+	if len(s) == 0 || strings.ContainsAny(s, " \t\"=") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// nestedLogMessage is printed by --output=json when the text of a message is
+// structured, so that the payload can be read without decoding it twice.
+type nestedLogMessage struct {
+	Name      string      `json:"name"`
+	Namespace string      `json:"namespace"`
+	Instance  string      `json:"instance"`
+	Timestamp time.Time   `json:"timestamp"`
+	Text      interface{} `json:"text"`
+}
+
+// nestPayload returns the text of a message as a JSON object when it is a JSON
+// object or logfmt, keeping the order and the types of a JSON payload.
+func nestPayload(text string) (interface{}, bool) {
+	payload, format := parseLogPayload(text)
+	switch format {
+	case jsonPayload:
+		var b bytes.Buffer
+		if err := json.Compact(&b, []byte(strings.TrimSpace(text))); err != nil {
+			return nil, false
+		}
+		return json.RawMessage(b.Bytes()), true
+
+	case logfmtPayload:
+		return payload, true
+	}
+	return nil, false
+}
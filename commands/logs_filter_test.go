@@ -0,0 +1,114 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/openfaas/faas-provider/logs"
+)
+
+func Test_parseLogfmt(t *testing.T) {
+	cases := []struct {
+		text string
+		want map[string]interface{}
+	}{
+		{text: `level=warn msg="disk is low" free=10%`, want: map[string]interface{}{"level": "warn", "msg": "disk is low", "free": "10%"}},
+		{text: `msg="quoted \"word\"" empty=`, want: map[string]interface{}{"msg": `quoted "word"`, "empty": ""}},
+		{text: "Forked fprocess", want: nil},
+		{text: "level=info and some text", want: nil},
+		{text: `msg="unterminated`, want: nil},
+		{text: "", want: nil},
+	}
+
+	for _, tc := range cases {
+		if got := parseLogfmt(tc.text); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%q: want %v, got: %v", tc.text, tc.want, got)
+		}
+	}
+}
+
+func Test_logFilter_apply(t *testing.T) {
+	cases := []struct {
+		name     string
+		grep     string
+		exclude  string
+		level    string
+		fields   []string
+		json     bool
+		text     string
+		wantKeep bool
+		wantText string
+	}{
+		{name: "grep match", grep: "time(out)?", text: "request timeout", wantKeep: true, wantText: "request timeout"},
+		{name: "grep miss", grep: "timeout", text: "ok", wantKeep: false},
+		{name: "exclude", exclude: "healthz", text: "GET /healthz", wantKeep: false},
+		{name: "json level above", level: "warn", text: `{"level":"error","msg":"failed"}`, wantKeep: true, wantText: `{"level":"error","msg":"failed"}`},
+		{name: "json level below", level: "warn", text: `{"level":"info","msg":"ok"}`, wantKeep: false},
+		{name: "numeric level", level: "warn", text: `{"level":40,"msg":"slow"}`, wantKeep: true, wantText: `{"level":40,"msg":"slow"}`},
+		{name: "logfmt level", level: "WARN", text: `lvl=warning msg=slow`, wantKeep: true, wantText: `lvl=warning msg=slow`},
+		{name: "no level", level: "warn", text: "plain text line", wantKeep: false},
+		{name: "fields from json", fields: []string{"msg", "req.id", "missing"}, text: `{"level":"info","msg":"done ok","req":{"id":7}}`, wantKeep: true, wantText: `msg="done ok" req.id=7`},
+		{name: "fields as json", fields: []string{"msg", "req.id"}, json: true, text: `{"msg":"done","req":{"id":7}}`, wantKeep: true, wantText: `{"msg":"done","req.id":7}`},
+		{name: "fields from logfmt", fields: []string{"msg"}, json: true, text: `level=info msg=done`, wantKeep: true, wantText: `msg=done`},
+		{name: "fields on plain text", fields: []string{"msg"}, text: "plain text line", wantKeep: true, wantText: "plain text line"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := newLogFilter(tc.grep, tc.exclude, tc.level, tc.fields, tc.json)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg, keep := f.apply(logs.Message{Text: tc.text})
+			if keep != tc.wantKeep {
+				t.Fatalf("want keep %t, got: %t", tc.wantKeep, keep)
+			}
+			if keep && msg.Text != tc.wantText {
+				t.Fatalf("want text %q, got: %q", tc.wantText, msg.Text)
+			}
+		})
+	}
+}
+
+func Test_newLogFilter_Invalid(t *testing.T) {
+	if _, err := newLogFilter("(", "", "", nil, false); err == nil {
+		t.Errorf("want an error for an invalid --grep")
+	}
+	if _, err := newLogFilter("", "", "loud", nil, false); err == nil {
+		t.Errorf("want an error for an unknown --level")
+	}
+}
+
+func Test_JSONLogFormatter_NestsPayload(t *testing.T) {
+	ts := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		text string
+		want string
+	}{
+		{
+			text: "{\"level\": \"info\", \"count\": 2}\n",
+			want: `{"name":"fn","namespace":"","instance":"","timestamp":"2009-11-10T23:00:00Z","text":{"level":"info","count":2}}`,
+		},
+		{
+			text: `level=info msg="hello world"`,
+			want: `{"name":"fn","namespace":"","instance":"","timestamp":"2009-11-10T23:00:00Z","text":{"level":"info","msg":"hello world"}}`,
+		},
+		{
+			text: "plain text\n",
+			want: `{"name":"fn","namespace":"","instance":"","timestamp":"2009-11-10T23:00:00Z","text":"plain text\n"}`,
+		},
+	}
+
+	for _, tc := range cases {
+		got := JSONFormatMessage(logs.Message{Name: "fn", Timestamp: ts, Text: tc.text}, "", false, false)
+		if got != tc.want {
+			t.Errorf("want %s, got: %s", tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/openfaas/faas-cli/proxy"
+	"github.com/openfaas/faas-provider/logs"
+)
+
+// followForeverPolicy is the wait between attempts to reconnect after a
+// log stream has failed.
+var followForeverPolicy = proxy.RetryPolicy{
+	MinBackoff: time.Second,
+	MaxBackoff: 30 * time.Second,
+}
+
+// minHealthyStream is how long a stream which delivered no messages has to
+// stay open before it is not counted as a failure.
+var minHealthyStream = time.Second
+
+// followForever returns an opener which reconnects to the log stream each
+// time it ends, until the context is cancelled.
+func followForever(open logStreamOpener, policy proxy.RetryPolicy) logStreamOpener {
+	return func(ctx context.Context, request logs.Request) (<-chan logs.Message, error) {
+		return followLogs(ctx, open, request, policy)
+	}
+}
+
+// followLogs opens the stream once, returning the error when that fails, then
+// reconnects with Since set to the last timestamp seen whenever the stream
+// closes. Messages seen before a reconnect are dropped, failures to reconnect
+// are printed and retried with a backoff.
+func followLogs(ctx context.Context, open logStreamOpener, request logs.Request, policy proxy.RetryPolicy) (<-chan logs.Message, error) {
+	events, err := open(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan logs.Message)
+	go func() {
+		defer close(out)
+
+		seen := newLogDeduper()
+		failures := 0
+
+		for {
+			started := time.Now()
+			received := 0
+			for msg := range events {
+				if seen.seen(msg) {
+					continue
+				}
+				received++
+
+				select {
+				case out <- msg:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if ctx.Err() != nil {
+				return
+			}
+
+			if received > 0 || time.Since(started) >= minHealthyStream {
+				failures = 0
+			} else {
+				failures++
+			}
+
+			if since, ok := seen.since(); ok {
+				request.Since = &since
+				request.Tail = 0
+			}
+
+			for {
+				if failures > 0 {
+					wait := policy.Backoff(failures - 1)
+					fmt.Fprintf(os.Stderr, "Log stream for %s ended, reconnecting in %s\n", request.Name, wait.Round(time.Millisecond))
+
+					select {
+					case <-time.After(wait):
+					case <-ctx.Done():
+						return
+					}
+				}
+
+				events, err = open(ctx, request)
+				if err == nil {
+					break
+				}
+				if ctx.Err() != nil {
+					return
+				}
+
+				failures++
+				fmt.Fprintf(os.Stderr, "Unable to reconnect to the log stream for %s: %s\n", request.Name, err)
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// logDeduper remembers the messages with a timestamp in the same second as
+// the last one seen or later, which is what a reconnect with Since can send
+// again, as Since is only precise to the second.
+type logDeduper struct {
+	last time.Time
+	keys map[string]time.Time
+}
+
+func newLogDeduper() *logDeduper {
+	return &logDeduper{keys: map[string]time.Time{}}
+}
+
+// seen records msg and reports whether it was already recorded
+func (d *logDeduper) seen(msg logs.Message) bool {
+	key := msg.Timestamp.Format(time.RFC3339Nano) + "\x00" + msg.Instance + "\x00" + msg.Text
+	if _, ok := d.keys[key]; ok {
+		return true
+	}
+	d.keys[key] = msg.Timestamp
+
+	if msg.Timestamp.After(d.last) {
+		cutoff := d.last.Truncate(time.Second)
+		d.last = msg.Timestamp
+
+		if next := d.last.Truncate(time.Second); next.After(cutoff) {
+			for k, ts := range d.keys {
+				if ts.Before(next) {
+					delete(d.keys, k)
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// since returns the timestamp to reconnect from, or false when nothing has
+// been seen yet
+func (d *logDeduper) since() (time.Time, bool) {
+	return d.last, !d.last.IsZero()
+}
@@ -0,0 +1,112 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openfaas/faas-cli/proxy"
+	"github.com/openfaas/faas-provider/logs"
+)
+
+func Test_followLogs_ReconnectsWithoutDuplicates(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	first := logs.Message{Instance: "a", Text: "first", Timestamp: base.Add(500 * time.Millisecond)}
+	second := logs.Message{Instance: "a", Text: "second", Timestamp: base.Add(1200 * time.Millisecond)}
+	third := logs.Message{Instance: "b", Text: "third", Timestamp: base.Add(1700 * time.Millisecond)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	requests := []logs.Request{}
+	open := func(ctx context.Context, request logs.Request) (<-chan logs.Message, error) {
+		requests = append(requests, request)
+
+		events := make(chan logs.Message, 3)
+		switch len(requests) {
+		case 1:
+			events <- first
+			events <- second
+		case 2:
+			return nil, fmt.Errorf("connection refused")
+		case 3:
+			events <- second
+			events <- third
+		default:
+			cancel()
+		}
+		close(events)
+		return events, nil
+	}
+
+	policy := proxy.RetryPolicy{MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	stream, err := followLogs(ctx, open, logs.Request{Name: "fn", Tail: 5, Follow: true}, policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := []string{}
+	for msg := range stream {
+		got = append(got, msg.Text)
+	}
+
+	if strings.Join(got, " ") != "first second third" {
+		t.Fatalf("want each message once, got: %q", got)
+	}
+
+	if len(requests) != 4 {
+		t.Fatalf("want 4 connection attempts, got: %d", len(requests))
+	}
+	if requests[0].Since != nil || requests[0].Tail != 5 {
+		t.Fatalf("want the first request unchanged, got: %s", requests[0])
+	}
+	if requests[2].Since == nil || !requests[2].Since.Equal(second.Timestamp) || requests[2].Tail != 0 {
+		t.Fatalf("want a reconnect since %s, got: %s", second.Timestamp, requests[2])
+	}
+	if !requests[3].Since.Equal(third.Timestamp) {
+		t.Fatalf("want a reconnect since %s, got: %s", third.Timestamp, requests[3])
+	}
+}
+
+func Test_followLogs_FirstConnectionFails(t *testing.T) {
+	open := func(ctx context.Context, request logs.Request) (<-chan logs.Message, error) {
+		return nil, fmt.Errorf("unauthorized access")
+	}
+
+	if _, err := followLogs(context.Background(), open, logs.Request{Name: "fn"}, followForeverPolicy); err == nil {
+		t.Fatalf("want the error from the first connection")
+	}
+}
+
+func Test_logDeduper_ForgetsOlderSeconds(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	d := newLogDeduper()
+
+	old := logs.Message{Text: "old", Timestamp: base.Add(100 * time.Millisecond)}
+	if d.seen(old) || !d.seen(old) {
+		t.Fatalf("want a message to be seen only after it was recorded")
+	}
+
+	d.seen(logs.Message{Text: "new", Timestamp: base.Add(2 * time.Second)})
+	if len(d.keys) != 1 {
+		t.Fatalf("want messages before the last second to be forgotten, got: %d", len(d.keys))
+	}
+}
+
+func Test_logs_FollowForeverWithoutTail(t *testing.T) {
+	resetForTest()
+	defer func() {
+		logFlagValues.followForever = false
+		logFlagValues.tail = true
+	}()
+
+	faasCmd.SetArgs([]string{"logs", "--follow-forever", "--tail=false", "fn1"})
+	if err := faasCmd.Execute(); err == nil {
+		t.Fatalf("want an error for --follow-forever with --tail=false")
+	}
+}
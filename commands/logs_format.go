@@ -2,9 +2,13 @@ package commands
 
 import (
 	"encoding/json"
+	"fmt"
+	"os"
 	"strings"
+	"text/template"
 
 	"github.com/openfaas/faas-cli/flags"
+	"github.com/openfaas/faas-cli/output"
 	"github.com/openfaas/faas-provider/logs"
 )
 
@@ -23,9 +27,49 @@ func GetLogFormatter(name string) LogFormatter {
 	}
 }
 
+// NewTemplateLogFormatter returns a LogFormatter which renders each message with
+// a text/template, the options are ignored. Besides the functions of the output
+// package, "parse" returns the fields of a JSON or logfmt line.
+func NewTemplateLogFormatter(text string) (LogFormatter, error) {
+	tmpl, err := output.NewTemplate(text, template.FuncMap{
+		"parse": parseLogFields,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return func(msg logs.Message, timeFormat string, includeName, includeInstance bool) string {
+		msg.Text = strings.TrimRight(msg.Text, "\n")
+
+		out, err := tmpl.String(msg)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return msg.Text
+		}
+		return out
+	}, nil
+}
+
+// parseLogFields returns the fields of a structured log line, or nil
+func parseLogFields(text string) map[string]interface{} {
+	payload, _ := parseLogPayload(text)
+	return payload
+}
+
 // JSONFormatMessage is a JSON formatting for log messages, the options are ignored and the entire log
-// message json serialized
+// message json serialized. When the text is a JSON object or logfmt, it is nested as an object.
 func JSONFormatMessage(msg logs.Message, timeFormat string, includeName, includeInstance bool) string {
+	if payload, ok := nestPayload(msg.Text); ok {
+		b, _ := json.Marshal(nestedLogMessage{
+			Name:      msg.Name,
+			Namespace: msg.Namespace,
+			Instance:  msg.Instance,
+			Timestamp: msg.Timestamp,
+			Text:      payload,
+		})
+		return string(b)
+	}
+
 	// error really can't happen here because of how simple the msg object is
 	b, _ := json.Marshal(msg)
 	return string(b)
@@ -103,3 +103,37 @@ func Test_KeyValueLogFormatter(t *testing.T) {
 		})
 	}
 }
+
+func Test_TemplateLogFormatter(t *testing.T) {
+	ts := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name   string
+		format string
+		text   string
+		want   string
+	}{
+		{"fields and helpers", `{{.Timestamp.Format "15:04:05"}} {{.Instance | trunc 8}} {{.Text}}`, "test message\n", "23:00:00 123test- test message"},
+		{"parse json", `{{with parse .Text}}{{.level}}: {{.msg}}{{end}}`, `{"level":"warn","msg":"disk low"}`, "warn: disk low"},
+		{"parse logfmt", `{{(parse .Text).msg}}`, `level=info msg="hello world"`, "hello world"},
+		{"parse plain text", `{{with parse .Text}}{{.msg}}{{else}}{{.Text}}{{end}}`, "plain", "plain"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			formatter, err := NewTemplateLogFormatter(tc.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			msg := logs.Message{Timestamp: ts, Name: "test-func", Instance: "123test-abc", Text: tc.text}
+			if got := formatter(msg, "", false, false); got != tc.want {
+				t.Fatalf("want %q, got: %q", tc.want, got)
+			}
+		})
+	}
+
+	if _, err := NewTemplateLogFormatter("{{.Text"); err == nil {
+		t.Fatalf("want an error for an invalid template")
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/morikuni/aec"
+	"github.com/openfaas/faas-cli/proxy"
+	"github.com/openfaas/faas-provider/logs"
+	"github.com/openfaas/go-sdk/stack"
+	"github.com/spf13/cobra"
+)
+
+// logMergeWindow is how long a message from one of several streams is held
+// back, so that a message from a slower stream can be printed before it.
+var logMergeWindow = 250 * time.Millisecond
+
+var logPrefixColors = []aec.ANSI{aec.CyanF, aec.GreenF, aec.YellowF, aec.MagentaF, aec.BlueF, aec.LightCyanF, aec.LightGreenF, aec.LightMagentaF}
+
+// logTarget is a function to stream the logs of
+type logTarget struct {
+	Name      string
+	Namespace string
+}
+
+// logTargetsFromStack returns the functions in the stack file, sorted by
+// name, with the namespace given by --namespace taking priority.
+func logTargetsFromStack(services stack.Services) []logTarget {
+	targets := []logTarget{}
+	for name, function := range services.Functions {
+		targets = append(targets, logTarget{Name: name, Namespace: getNamespace(functionNamespace, function.Namespace)})
+	}
+
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].Name < targets[j].Name
+	})
+	return targets
+}
+
+// listLogTargets returns every function deployed to namespace, sorted by name
+func listLogTargets(ctx context.Context, client *proxy.Client, namespace string) ([]logTarget, error) {
+	functions, err := client.ListFunctions(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := []logTarget{}
+	for _, fn := range functions {
+		targets = append(targets, logTarget{Name: fn.Name, Namespace: getNamespace(namespace, fn.Namespace)})
+	}
+
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].Name < targets[j].Name
+	})
+	return targets, nil
+}
+
+// logStreamOpener opens a stream of log messages, such as proxy.Client.GetLogs
+type logStreamOpener func(ctx context.Context, request logs.Request) (<-chan logs.Message, error)
+
+// openLogStreams opens a stream for each target. A message without a
+// function name is given the name of the target, so that it can be told apart
+// once the streams have been merged.
+func openLogStreams(ctx context.Context, open logStreamOpener, request logs.Request, targets []logTarget) ([]<-chan logs.Message, error) {
+	streams := []<-chan logs.Message{}
+
+	for _, target := range targets {
+		request.Name = target.Name
+		request.Namespace = target.Namespace
+
+		events, err := open(ctx, request)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get logs for %s: %w", target.Name, err)
+		}
+
+		named := make(chan logs.Message)
+		go func(name string) {
+			defer close(named)
+			for msg := range events {
+				if len(msg.Name) == 0 {
+					msg.Name = name
+				}
+				named <- msg
+			}
+		}(target.Name)
+
+		streams = append(streams, named)
+	}
+
+	return streams, nil
+}
+
+// mergeLogStreams merges the streams into one channel which is closed when
+// every stream has closed. Each message is held back for window after it
+// arrives, and the messages held back are released in timestamp order.
+func mergeLogStreams(streams []<-chan logs.Message, window time.Duration) <-chan logs.Message {
+	type arrival struct {
+		msg logs.Message
+		at  time.Time
+	}
+
+	in := make(chan arrival)
+	var wg sync.WaitGroup
+	for _, stream := range streams {
+		wg.Add(1)
+		go func(stream <-chan logs.Message) {
+			defer wg.Done()
+			for msg := range stream {
+				in <- arrival{msg: msg, at: time.Now()}
+			}
+		}(stream)
+	}
+	go func() {
+		wg.Wait()
+		close(in)
+	}()
+
+	out := make(chan logs.Message, 1000)
+	go func() {
+		defer close(out)
+
+		pending := []arrival{}
+		ticker := time.NewTicker(max(window/4, time.Millisecond))
+		defer ticker.Stop()
+
+		for {
+			select {
+			case a, ok := <-in:
+				if !ok {
+					for _, p := range pending {
+						out <- p.msg
+					}
+					return
+				}
+
+				i := sort.Search(len(pending), func(i int) bool {
+					return pending[i].msg.Timestamp.After(a.msg.Timestamp)
+				})
+				pending = append(pending, arrival{})
+				copy(pending[i+1:], pending[i:])
+				pending[i] = a
+
+			case now := <-ticker.C:
+				released := 0
+				for released < len(pending) && now.Sub(pending[released].at) >= window {
+					out <- pending[released].msg
+					released++
+				}
+				pending = pending[released:]
+			}
+		}
+	}()
+
+	return out
+}
+
+// logPrefixer gives each function a colored name, padded to the longest
+// name, to be printed before each line in plain mode.
+type logPrefixer struct {
+	width  int
+	colors map[string]aec.ANSI
+	color  bool
+}
+
+func newLogPrefixer(targets []logTarget, color bool) *logPrefixer {
+	p := &logPrefixer{colors: map[string]aec.ANSI{}, color: color}
+	for i, target := range targets {
+		p.width = max(p.width, len(target.Name))
+		if _, ok := p.colors[target.Name]; !ok {
+			p.colors[target.Name] = logPrefixColors[i%len(logPrefixColors)]
+		}
+	}
+	return p
+}
+
+func (p *logPrefixer) prefix(name string) string {
+	prefix := fmt.Sprintf("%-*s |", p.width, name)
+	if c, ok := p.colors[name]; ok && p.color {
+		prefix = c.Apply(prefix)
+	}
+	return prefix + " "
+}
+
+// isTerminal reports whether f is attached to a terminal, so that colors
+// are left out when the output is piped or redirected.
+func isTerminal(f *os.File) bool {
+	stat, err := f.Stat()
+	return err == nil && (stat.Mode()&os.ModeCharDevice) != 0
+}
+
+func validateLogsArgs(cmd *cobra.Command, args []string) error {
+	if logFlagValues.all && len(args) > 0 {
+		return fmt.Errorf("--all cannot be used with a function name")
+	}
+	if len(args) == 0 && !logFlagValues.all && len(yamlFile) == 0 {
+		return fmt.Errorf("function name is required")
+	}
+	if len(logFlagValues.format) > 0 && cmd.Flags().Changed("output") {
+		return fmt.Errorf("--format cannot be used with --output")
+	}
+	if logFlagValues.followForever && !logFlagValues.tail {
+		return fmt.Errorf("--follow-forever cannot be used with --tail=false")
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openfaas/faas-cli/test"
+	"github.com/openfaas/faas-provider/logs"
+	types "github.com/openfaas/faas-provider/types"
+)
+
+func Test_mergeLogStreams_OrdersByTimestamp(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	a := make(chan logs.Message, 2)
+	b := make(chan logs.Message, 2)
+	a <- logs.Message{Name: "a", Text: "third", Timestamp: base.Add(3 * time.Second)}
+	b <- logs.Message{Name: "b", Text: "first", Timestamp: base.Add(1 * time.Second)}
+	a <- logs.Message{Name: "a", Text: "fourth", Timestamp: base.Add(4 * time.Second)}
+	b <- logs.Message{Name: "b", Text: "second", Timestamp: base.Add(2 * time.Second)}
+	close(a)
+	close(b)
+
+	got := []string{}
+	for msg := range mergeLogStreams([]<-chan logs.Message{a, b}, time.Second) {
+		got = append(got, msg.Text)
+	}
+
+	want := "first second third fourth"
+	if strings.Join(got, " ") != want {
+		t.Fatalf("want %q, got: %q", want, strings.Join(got, " "))
+	}
+}
+
+func Test_logPrefixer(t *testing.T) {
+	p := newLogPrefixer([]logTarget{{Name: "api"}, {Name: "resize-image"}}, false)
+
+	if got := p.prefix("api"); got != "api          | " {
+		t.Fatalf("want a padded prefix, got: %q", got)
+	}
+
+	colored := newLogPrefixer([]logTarget{{Name: "api"}, {Name: "resize-image"}}, true)
+	if got := colored.prefix("api"); got == p.prefix("api") || !strings.Contains(got, "api          |") {
+		t.Fatalf("want a colored prefix, got: %q", got)
+	}
+}
+
+// newLogsGateway serves the logs of each function, one line per function
+// named in the name query parameter, and lists the functions given.
+func newLogsGateway(t *testing.T, functions ...string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/system/functions":
+			list := []types.FunctionStatus{}
+			for _, name := range functions {
+				list = append(list, types.FunctionStatus{Name: name})
+			}
+			json.NewEncoder(w).Encode(list)
+
+		case "/system/logs":
+			name := r.URL.Query().Get("name")
+			json.NewEncoder(w).Encode(logs.Message{
+				Name:      name,
+				Namespace: r.URL.Query().Get("namespace"),
+				Text:      "hello from " + name,
+				Timestamp: time.Date(2025, 1, 1, 0, 0, len(name), 0, time.UTC),
+			})
+
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func Test_logs_MultipleFunctions(t *testing.T) {
+	resetForTest()
+	defaultWindow := logMergeWindow
+	defer func() {
+		logMergeWindow = defaultWindow
+		functionNamespace = ""
+		logFlagValues = logFlags{timeFormat: logFlagValues.timeFormat}
+	}()
+	logMergeWindow = 10 * time.Millisecond
+
+	s := newLogsGateway(t, "fn1", "function-two")
+	defer s.Close()
+
+	dir := t.TempDir()
+	stackFile := filepath.Join(dir, "stack.yaml")
+	os.WriteFile(stackFile, []byte(`version: 1.0
+provider:
+  name: openfaas
+functions:
+  fn1:
+    image: fn1:latest
+  function-two:
+    image: function-two:latest
+  skipped:
+    image: skipped:latest
+`), 0600)
+
+	cases := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "names",
+			args: []string{"fn1", "function-two"},
+			want: "fn1          | hello from fn1\nfunction-two | hello from function-two\n",
+		},
+		{
+			name: "stack file with filter",
+			args: []string{"-f", stackFile, "--filter", "f*"},
+			want: "fn1          | hello from fn1\nfunction-two | hello from function-two\n",
+		},
+		{
+			name: "all",
+			args: []string{"--all", "--namespace", "dev"},
+			want: "fn1          | hello from fn1\nfunction-two | hello from function-two\n",
+		},
+		{
+			name: "keyvalue",
+			args: []string{"--all", "-o", "keyvalue"},
+			want: "name=\"fn1\" text=\"hello from fn1\" \nname=\"function-two\" text=\"hello from function-two\" \n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var err error
+			stdOut := test.CaptureStdout(func() {
+				faasCmd.SetArgs(append([]string{"logs", "--gateway=" + s.URL, "--tail=false", "--time-format="}, tc.args...))
+				err = faasCmd.Execute()
+			})
+			yamlFile = ""
+			filter = ""
+			logFlagValues.all = false
+			logFlagValues.logFormat = ""
+
+			if err != nil {
+				t.Fatalf("want no error, got: %s", err)
+			}
+			if stdOut != tc.want {
+				t.Fatalf("want %q, got: %q", tc.want, stdOut)
+			}
+		})
+	}
+}
+
+func Test_logs_AllWithName(t *testing.T) {
+	resetForTest()
+	defer func() { logFlagValues.all = false }()
+
+	faasCmd.SetArgs([]string{"logs", "--all", "fn1"})
+	if err := faasCmd.Execute(); err == nil {
+		t.Fatalf("want an error for --all with a function name")
+	}
+}
@@ -9,20 +9,24 @@ import (
 	"io"
 	"text/tabwriter"
 
+	"github.com/openfaas/faas-cli/output"
 	"github.com/openfaas/faas-provider/types"
 	"github.com/spf13/cobra"
 )
 
 var namespaceGetCmd = &cobra.Command{
-	Use:     `get NAME`,
-	Short:   "Get existing namespace",
-	Long:    "Get existing namespace",
-	Example: `  faas-cli namespace get NAME`,
+	Use:   `get NAME`,
+	Short: "Get existing namespace",
+	Long:  "Get existing namespace",
+	Example: `  faas-cli namespace get NAME
+  faas-cli namespace get NAME -o yaml`,
 	RunE:    get_namespace,
 	PreRunE: preGetNamespace,
 }
 
 func init() {
+	namespaceGetCmd.Flags().StringVarP(&outputFormat, "output", "o", "", output.FlagUsage)
+
 	namespaceCmd.AddCommand(namespaceGetCmd)
 }
 
@@ -39,6 +43,11 @@ func preGetNamespace(cmd *cobra.Command, args []string) error {
 }
 
 func get_namespace(cmd *cobra.Command, args []string) error {
+	printer, err := output.NewPrinter(outputFormat)
+	if err != nil {
+		return err
+	}
+
 	client, err := GetDefaultSDKClient()
 	if err != nil {
 		return err
@@ -51,12 +60,16 @@ func get_namespace(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	printNamespaceDetail(cmd.OutOrStdout(), res)
-
-	return nil
+	return printer.Print(cmd.OutOrStdout(), output.Result{
+		Object: res,
+		Names:  []string{res.Name},
+		Table: func(w io.Writer, wide bool) {
+			printNamespaceDetail(w, res, verbose || wide)
+		},
+	})
 }
 
-func printNamespaceDetail(dst io.Writer, nsDetail types.FunctionNamespace) {
+func printNamespaceDetail(dst io.Writer, nsDetail types.FunctionNamespace, verbose bool) {
 	w := tabwriter.NewWriter(dst, 0, 0, 1, ' ', tabwriter.TabIndent)
 	defer w.Flush()
 
@@ -3,7 +3,9 @@ package commands
 import (
 	"context"
 	"fmt"
+	"io"
 
+	"github.com/openfaas/faas-cli/output"
 	"github.com/spf13/cobra"
 )
 
@@ -13,6 +15,8 @@ func init() {
 	namespacesCmd.Flags().BoolVar(&tlsInsecure, "tls-no-verify", false, "Disable TLS validation")
 	namespacesCmd.Flags().StringVarP(&token, "token", "k", "", "Pass a JWT token to use instead of basic auth")
 
+	namespaceListCmd.Flags().StringVarP(&outputFormat, "output", "o", "", output.FlagUsage)
+
 	faasCmd.AddCommand(namespacesCmd)
 	namespaceCmd.AddCommand(namespaceListCmd)
 }
@@ -33,11 +37,17 @@ var namespaceListCmd = &cobra.Command{
 	Aliases: []string{"ls"},
 	Short:   "List OpenFaaS namespaces",
 	Long:    `Lists OpenFaaS namespaces for the given gateway URL`,
-	Example: `faas-cli namespace list`,
-	RunE:    runNamespaces,
+	Example: `  faas-cli namespace list
+  faas-cli namespace list -o json`,
+	RunE: runNamespaces,
 }
 
 func runNamespaces(cmd *cobra.Command, args []string) error {
+	printer, err := output.NewPrinter(outputFormat)
+	if err != nil {
+		return err
+	}
+
 	client, err := GetDefaultSDKClient()
 	if err != nil {
 		return err
@@ -47,13 +57,23 @@ func runNamespaces(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	printNamespaces(namespaces)
-	return nil
+
+	if namespaces == nil {
+		namespaces = []string{}
+	}
+
+	return printer.Print(cmd.OutOrStdout(), output.Result{
+		Object: namespaces,
+		Names:  namespaces,
+		Table: func(w io.Writer, wide bool) {
+			printNamespaces(w, namespaces)
+		},
+	})
 }
 
-func printNamespaces(namespaces []string) {
-	fmt.Print("Namespaces:\n")
+func printNamespaces(w io.Writer, namespaces []string) {
+	fmt.Fprint(w, "Namespaces:\n")
 	for _, v := range namespaces {
-		fmt.Printf(" - %s\n", v)
+		fmt.Fprintf(w, " - %s\n", v)
 	}
 }
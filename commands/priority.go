@@ -6,24 +6,42 @@ package commands
 import (
 	"fmt"
 	"strings"
+
+	"github.com/openfaas/faas-cli/config"
 )
 
 const (
 	openFaaSURLEnvironment      = "OPENFAAS_URL"
 	templateURLEnvironment      = "OPENFAAS_TEMPLATE_URL"
 	templateStoreURLEnvironment = "OPENFAAS_TEMPLATE_STORE_URL"
+	retriesEnvironment          = "OPENFAAS_RETRIES"
 	defaultFunctionNamespace    = ""
 )
 
+// gatewayFlagSet is true when --gateway was given, so that a value equal to
+// the default still takes priority over the stack file, OPENFAAS_URL and the
+// active context.
+var gatewayFlagSet bool
+
+// getGatewayURL picks the gateway from, in order: --gateway, a context given
+// with --context, the stack file, OPENFAAS_URL, the current context and then
+// the default.
 func getGatewayURL(argumentURL, defaultURL, yamlURL, environmentURL string) string {
 	var gatewayURL string
 
-	if len(argumentURL) > 0 && argumentURL != defaultURL {
+	cliContext, ok := activeContext()
+	hasContextGateway := ok && len(cliContext.Gateway) > 0
+
+	if len(argumentURL) > 0 && (gatewayFlagSet || argumentURL != defaultURL) {
 		gatewayURL = argumentURL
+	} else if hasContextGateway && len(contextName) > 0 {
+		gatewayURL = cliContext.Gateway
 	} else if len(yamlURL) > 0 && yamlURL != defaultURL {
 		gatewayURL = yamlURL
 	} else if len(environmentURL) > 0 {
 		gatewayURL = environmentURL
+	} else if hasContextGateway {
+		gatewayURL = cliContext.Gateway
 	} else {
 		gatewayURL = defaultURL
 	}
@@ -70,6 +88,20 @@ func getNamespace(flagNamespace, stackNamespace string) string {
 		return stackNamespace
 	}
 
+	if cliContext, ok := activeContext(); ok && len(cliContext.Namespace) > 0 {
+		return cliContext.Namespace
+	}
+
 	return defaultFunctionNamespace
 
 }
+
+// activeContext returns the context given with --context, or the current
+// context from the config file.
+var activeContext = func() (config.Context, bool) {
+	cliContext, ok, err := config.LookupContext(contextName)
+	if err != nil {
+		return config.Context{}, false
+	}
+	return cliContext, ok
+}
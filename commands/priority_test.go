@@ -1,6 +1,11 @@
 package commands
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/openfaas/faas-cli/config"
+	"github.com/spf13/cobra"
+)
 
 func Test_getTemplateStoreURL(t *testing.T) {
 	tests := []struct {
@@ -99,3 +104,83 @@ func Test_getOverrideNamespace(t *testing.T) {
 		})
 	}
 }
+
+func Test_getGatewayURL_FallsBackToContext(t *testing.T) {
+	defer func(fn func() (config.Context, bool)) { activeContext = fn }(activeContext)
+	activeContext = func() (config.Context, bool) {
+		return config.Context{Name: "staging", Gateway: "https://staging.example.com", Namespace: "staging-fn"}, true
+	}
+
+	if got := getGatewayURL("", defaultGateway, "", ""); got != "https://staging.example.com" {
+		t.Errorf("want context gateway, got: %s", got)
+	}
+
+	if got := getGatewayURL("", defaultGateway, "", "http://env:8080"); got != "http://env:8080" {
+		t.Errorf("want OPENFAAS_URL to take priority over the context, got: %s", got)
+	}
+
+	if got := getGatewayURL("http://flag:8080", defaultGateway, "", ""); got != "http://flag:8080" {
+		t.Errorf("want flag to take priority over the context, got: %s", got)
+	}
+
+	if got := getGatewayURL("", defaultGateway, "http://yaml:8080", ""); got != "http://yaml:8080" {
+		t.Errorf("want the stack file to take priority over the current context, got: %s", got)
+	}
+
+	contextName = "staging"
+	defer func() { contextName = "" }()
+
+	if got := getGatewayURL("", defaultGateway, "http://yaml:8080", "http://env:8080"); got != "https://staging.example.com" {
+		t.Errorf("want --context to take priority over the stack file and OPENFAAS_URL, got: %s", got)
+	}
+
+	if got := getGatewayURL("http://flag:8080", defaultGateway, "http://yaml:8080", ""); got != "http://flag:8080" {
+		t.Errorf("want flag to take priority over --context, got: %s", got)
+	}
+}
+
+func Test_getGatewayURL_ExplicitDefaultOverContext(t *testing.T) {
+	defer func(fn func() (config.Context, bool)) { activeContext = fn }(activeContext)
+	activeContext = func() (config.Context, bool) {
+		return config.Context{Name: "staging", Gateway: "https://staging.example.com"}, true
+	}
+	t.Setenv(config.ConfigLocationEnv, t.TempDir())
+
+	defer func() {
+		gateway = defaultGateway
+		gatewayFlagSet = false
+	}()
+
+	cmd := &cobra.Command{}
+	cmd.Flags().StringVarP(&gateway, "gateway", "g", defaultGateway, "")
+	if err := cmd.ParseFlags([]string{"-g", defaultGateway}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := preRunFaas(cmd, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := getGatewayURL(gateway, defaultGateway, "", ""); got != defaultGateway {
+		t.Errorf("want the gateway given with --gateway, got: %s", got)
+	}
+}
+
+func Test_getNamespace_FallsBackToContext(t *testing.T) {
+	defer func(fn func() (config.Context, bool)) { activeContext = fn }(activeContext)
+	activeContext = func() (config.Context, bool) {
+		return config.Context{Name: "staging", Gateway: "https://staging.example.com", Namespace: "staging-fn"}, true
+	}
+
+	if got := getNamespace("", ""); got != "staging-fn" {
+		t.Errorf("want context namespace, got: %s", got)
+	}
+
+	if got := getNamespace("", "stack-fn"); got != "stack-fn" {
+		t.Errorf("want stack namespace to take priority over the context, got: %s", got)
+	}
+
+	if got := getNamespace("flag-fn", "stack-fn"); got != "flag-fn" {
+		t.Errorf("want flag to take priority, got: %s", got)
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/openfaas/faas-cli/proxy"
+	"github.com/openfaas/faas-provider/types"
+	"github.com/openfaas/go-sdk/stack"
+)
+
+// stackLabel is set on every function deployed from a stack file, so that
+// functions which have since been removed from the file can be pruned.
+const stackLabel = "com.openfaas.stack"
+
+// maxLabelValue is the longest value allowed by Kubernetes for a label
+const maxLabelValue = 63
+
+var invalidLabelChars = regexp.MustCompile(`[^a-zA-Z0-9\-_.]+`)
+
+// getStackName returns the value used for the stack label. When no name is
+// given, one is made from the path of the stack file, see defaultStackName.
+func getStackName(flagStackName, stackFile string) string {
+	name := flagStackName
+	if len(name) == 0 {
+		name = defaultStackName(stackFile)
+	}
+
+	name = invalidLabelChars.ReplaceAllString(name, "-")
+	if len(name) > maxLabelValue {
+		if len(flagStackName) > 0 {
+			name = name[:maxLabelValue]
+		} else {
+			// keep the end of the path, which tells sibling stack files apart
+			name = name[len(name)-maxLabelValue:]
+		}
+	}
+
+	return strings.Trim(name, "-_.")
+}
+
+// defaultStackName is the path of the stack file without its extension,
+// from the root of the git repository which holds it, i.e.
+// "functions-stack" for "functions/stack.yaml", so that every checkout of the
+// repository gives the same name wherever it is cloned. Outside of a
+// repository, or for a remote stack file, the folder which holds the stack
+// file is used as well, i.e. "shop-stack" for "shop/stack.yaml". Sibling
+// stack files are given different names, so that pruning one doesn't remove
+// the functions of another.
+func defaultStackName(stackFile string) string {
+	if strings.HasPrefix(stackFile, "http://") || strings.HasPrefix(stackFile, "https://") {
+		file := strings.TrimSuffix(path.Base(stackFile), path.Ext(stackFile))
+		return path.Base(path.Dir(stackFile)) + "-" + file
+	}
+
+	abs, err := filepath.Abs(stackFile)
+	if err != nil {
+		return ""
+	}
+
+	root := findRepoRoot(filepath.Dir(abs))
+	if len(root) == 0 {
+		root = filepath.Dir(filepath.Dir(abs))
+	}
+
+	rel, err := filepath.Rel(root, strings.TrimSuffix(abs, filepath.Ext(abs)))
+	if err != nil {
+		return ""
+	}
+
+	return strings.ReplaceAll(filepath.ToSlash(rel), "/", "-")
+}
+
+// findRepoRoot returns the nearest folder at or above dir which holds a .git
+// folder or file, or an empty string when there is none.
+func findRepoRoot(dir string) string {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// stackFunctionNames returns the names of the functions in a stack file,
+// grouped by the namespace they are deployed to.
+func stackFunctionNames(services stack.Services) map[string]map[string]bool {
+	inStack := map[string]map[string]bool{}
+	for name, function := range services.Functions {
+		namespace := getNamespace(functionNamespace, function.Namespace)
+		if _, ok := inStack[namespace]; !ok {
+			inStack[namespace] = map[string]bool{}
+		}
+		inStack[namespace][name] = true
+	}
+	return inStack
+}
+
+// findPruneCandidates lists the functions in each namespace used by the stack
+// which carry the stack label for stackName, but are no longer in the stack.
+func findPruneCandidates(ctx context.Context, client *proxy.Client, stackName string, inStack map[string]map[string]bool) ([]types.FunctionStatus, error) {
+	candidates := []types.FunctionStatus{}
+
+	for namespace, names := range inStack {
+		functions, err := client.ListFunctions(ctx, namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, fn := range functions {
+			if fn.Labels == nil || (*fn.Labels)[stackLabel] != stackName {
+				continue
+			}
+
+			if !names[fn.Name] {
+				if len(fn.Namespace) == 0 {
+					fn.Namespace = namespace
+				}
+				candidates = append(candidates, fn)
+			}
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Namespace != candidates[j].Namespace {
+			return candidates[i].Namespace < candidates[j].Namespace
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+
+	return candidates, nil
+}
+
+func printPruneCandidates(w io.Writer, candidates []types.FunctionStatus) {
+	if len(candidates) == 0 {
+		fmt.Fprintln(w, "No functions to prune.")
+		return
+	}
+
+	fmt.Fprintf(w, "Functions to prune: %d\n", len(candidates))
+	for _, fn := range candidates {
+		fmt.Fprintf(w, "\t - %s\n", functionRef(fn.Name, fn.Namespace))
+	}
+}
+
+// confirmPrune asks the user to confirm the removal of the functions
+func confirmPrune(in io.Reader, out io.Writer, candidates []types.FunctionStatus) bool {
+	fmt.Fprintf(out, "Remove %d function(s)? [y/N]: ", len(candidates))
+
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && len(answer) == 0 {
+		fmt.Fprintln(out)
+		return false
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// runPrune removes the functions which were deployed from the stack file but
+// have since been removed from it. The full stack file is read, ignoring any
+// filter or regex, so that functions which were filtered out are kept.
+func runPrune(ctx context.Context, client *proxy.Client, deployFlags DeployFlags) error {
+	if len(deployFlags.stackName) == 0 {
+		return fmt.Errorf("unable to determine the stack name for --prune, set one with --stack-name")
+	}
+
+	services, err := stack.ParseYAMLFile(yamlFile, "", "", envsubst)
+	if err != nil {
+		return err
+	}
+
+	candidates, err := findPruneCandidates(ctx, client, deployFlags.stackName, stackFunctionNames(*services))
+	if err != nil {
+		return err
+	}
+
+	printPruneCandidates(os.Stdout, candidates)
+	if len(candidates) == 0 || deployFlags.dryRun {
+		return nil
+	}
+
+	if !deployFlags.yes && !confirmPrune(os.Stdin, os.Stdout, candidates) {
+		fmt.Println("Prune cancelled.")
+		return nil
+	}
+
+	return pruneFunctions(ctx, client, candidates)
+}
+
+// pruneFunctions removes each of the candidates from the gateway
+func pruneFunctions(ctx context.Context, client *proxy.Client, candidates []types.FunctionStatus) error {
+	var failed []string
+	for _, fn := range candidates {
+		fmt.Printf("Pruning: %s\n", functionRef(fn.Name, fn.Namespace))
+
+		if err := client.DeleteFunction(ctx, fn.Name, fn.Namespace); err != nil {
+			failed = append(failed, fmt.Sprintf("function '%s' failed to prune: %s", fn.Name, err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%s", strings.Join(failed, "\n"))
+	}
+
+	return nil
+}
+
+func functionRef(name, namespace string) string {
+	if len(namespace) > 0 {
+		return name + "." + namespace
+	}
+	return name
+}
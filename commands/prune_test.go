@@ -0,0 +1,159 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openfaas/faas-cli/proxy"
+	"github.com/openfaas/faas-cli/test"
+	"github.com/openfaas/faas-provider/types"
+)
+
+func Test_getStackName(t *testing.T) {
+	cases := []struct {
+		title     string
+		flagName  string
+		stackFile string
+		want      string
+	}{
+		{title: "flag takes priority", flagName: "payments", stackFile: "/src/shop/stack.yaml", want: "payments"},
+		{title: "folder and name of stack file", stackFile: "/src/shop/stack.yaml", want: "shop-stack"},
+		{title: "remote stack file", stackFile: "https://example.com/stacks/shop/stack.yaml", want: "shop-stack"},
+		{title: "invalid characters replaced", flagName: "my shop!", want: "my-shop"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.title, func(t *testing.T) {
+			if got := getStackName(c.flagName, c.stackFile); got != c.want {
+				t.Errorf("want %q, got %q", c.want, got)
+			}
+		})
+	}
+}
+
+func Test_getStackName_InRepository(t *testing.T) {
+	checkouts := []string{
+		filepath.Join(t.TempDir(), "builds", "x", "src"),
+		filepath.Join(t.TempDir(), "code", "shop"),
+	}
+
+	for _, repo := range checkouts {
+		if err := os.MkdirAll(filepath.Join(repo, ".git"), 0700); err != nil {
+			t.Fatal(err)
+		}
+
+		stackFile := filepath.Join(repo, "functions", "stack.yaml")
+		if got, want := getStackName("", stackFile), "functions-stack"; got != want {
+			t.Errorf("%s: want %q, got %q", repo, want, got)
+		}
+	}
+}
+
+func Test_findPruneCandidates_SiblingStackFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "functions")
+	stackNames := map[string]string{
+		"api":    getStackName("", filepath.Join(dir, "api.yaml")),
+		"worker": getStackName("", filepath.Join(dir, "worker.yaml")),
+	}
+
+	if stackNames["api"] == stackNames["worker"] {
+		t.Fatalf("want sibling stack files to have different stack names, got %q for both", stackNames["api"])
+	}
+
+	s := test.MockHttpServer(t, []test.Request{
+		{
+			Method:             http.MethodGet,
+			Uri:                "/system/functions",
+			ResponseStatusCode: http.StatusOK,
+			ResponseBody: []types.FunctionStatus{
+				{Name: "api", Labels: &map[string]string{stackLabel: stackNames["api"]}},
+				{Name: "worker", Labels: &map[string]string{stackLabel: stackNames["worker"]}},
+			},
+		},
+	})
+	defer s.Close()
+
+	cliAuth, err := proxy.NewCLIAuth("", s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := proxy.NewClient(cliAuth, s.URL, nil, &commandTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inStack := map[string]map[string]bool{"": {"api": true}}
+	candidates, err := findPruneCandidates(context.Background(), client, stackNames["api"], inStack)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(candidates) != 0 {
+		t.Fatalf("want no functions from the sibling stack file to be pruned, got %v", candidates)
+	}
+}
+
+func Test_findPruneCandidates(t *testing.T) {
+	s := test.MockHttpServer(t, []test.Request{
+		{
+			Method:             http.MethodGet,
+			Uri:                "/system/functions",
+			ResponseStatusCode: http.StatusOK,
+			ResponseBody: []types.FunctionStatus{
+				{Name: "kept", Namespace: "openfaas-fn", Labels: &map[string]string{stackLabel: "shop"}},
+				{Name: "removed", Namespace: "openfaas-fn", Labels: &map[string]string{stackLabel: "shop"}},
+				{Name: "other-stack", Namespace: "openfaas-fn", Labels: &map[string]string{stackLabel: "billing"}},
+				{Name: "unmanaged", Namespace: "openfaas-fn"},
+			},
+		},
+	})
+	defer s.Close()
+
+	cliAuth, err := proxy.NewCLIAuth("", s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := proxy.NewClient(cliAuth, s.URL, nil, &commandTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inStack := map[string]map[string]bool{"": {"kept": true}}
+	candidates, err := findPruneCandidates(context.Background(), client, "shop", inStack)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(candidates) != 1 || candidates[0].Name != "removed" {
+		t.Fatalf("want only the removed function to be pruned, got %v", candidates)
+	}
+}
+
+func Test_confirmPrune(t *testing.T) {
+	candidates := []types.FunctionStatus{{Name: "removed"}}
+
+	cases := map[string]bool{
+		"y\n":   true,
+		"YES\n": true,
+		"n\n":   false,
+		"\n":    false,
+		"":      false,
+	}
+
+	for input, want := range cases {
+		out := bytes.Buffer{}
+		if got := confirmPrune(strings.NewReader(input), &out, candidates); got != want {
+			t.Errorf("input %q: want %v, got %v", input, want, got)
+		}
+	}
+}
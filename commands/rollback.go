@@ -0,0 +1,86 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openfaas/faas-cli/history"
+	"github.com/openfaas/faas-cli/proxy"
+	"github.com/spf13/cobra"
+)
+
+var toRevision int
+
+func init() {
+	rollbackCmd.Flags().StringVarP(&gateway, "gateway", "g", defaultGateway, "Gateway URL starting with http(s)://")
+	rollbackCmd.Flags().StringVarP(&functionNamespace, "namespace", "n", "", "Namespace of the function")
+	rollbackCmd.Flags().BoolVar(&tlsInsecure, "tls-no-verify", false, "Disable TLS validation")
+	rollbackCmd.Flags().StringVarP(&token, "token", "k", "", "Pass a JWT token to use instead of basic auth")
+	rollbackCmd.Flags().IntVar(&toRevision, "to-revision", 0, "Revision to roll back to, defaults to the previous revision")
+
+	faasCmd.AddCommand(rollbackCmd)
+}
+
+var rollbackCmd = &cobra.Command{
+	Use:   `rollback FUNCTION_NAME [--to-revision REVISION] [--gateway GATEWAY_URL] [--namespace NAMESPACE]`,
+	Short: "Re-deploy an earlier revision of a function",
+	Long: `Re-deploys a revision of a function recorded by "faas-cli deploy", use
+"faas-cli history" to list the revisions available. Rolling back records a new
+revision.`,
+	Example: `  faas-cli rollback figlet
+  faas-cli rollback figlet --to-revision 3
+  faas-cli rollback figlet --namespace staging-fn`,
+	RunE: runRollback,
+}
+
+func runRollback(cmd *cobra.Command, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("please provide a name for the function")
+	}
+	functionName := args[0]
+
+	if toRevision < 0 {
+		return fmt.Errorf("the --to-revision flag must be greater than 0")
+	}
+
+	gatewayAddress, namespace := revisionKey(functionName)
+
+	revision, err := history.Get(gatewayAddress, namespace, functionName, toRevision)
+	if err != nil {
+		return err
+	}
+
+	cliAuth, err := proxy.NewCLIAuth(token, gatewayAddress)
+	if err != nil {
+		return err
+	}
+	transport := GetDefaultCLITransport(tlsInsecure, &commandTimeout)
+	proxyClient, err := proxy.NewClient(cliAuth, gatewayAddress, transport, &commandTimeout)
+	if err != nil {
+		return err
+	}
+
+	spec := revision.Spec
+	spec.Update = true
+	spec.Replace = false
+	spec.TLSInsecure = tlsInsecure
+	spec.Token = token
+
+	fmt.Printf("Rolling back %s to revision %d: %s.\n", functionName, revision.Revision, spec.Image)
+
+	if msg := checkTLSInsecure(gatewayAddress, spec.TLSInsecure); len(msg) > 0 {
+		fmt.Println(msg)
+	}
+
+	statusCode := proxyClient.DeployFunction(context.Background(), &spec)
+	if badStatusCode(statusCode) {
+		return deployFailed(map[string]int{functionName: statusCode})
+	}
+
+	saveRevision(gatewayAddress, revision.GitSHA, &spec)
+
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/openfaas/faas-cli/config"
+	"github.com/openfaas/faas-cli/history"
+	"github.com/openfaas/faas-cli/proxy"
+	"github.com/openfaas/faas-cli/test"
+)
+
+func Test_rollback_ToRevision(t *testing.T) {
+	t.Setenv(config.ConfigLocationEnv, t.TempDir())
+
+	s := test.MockHttpServer(t, []test.Request{
+		{
+			Method:             http.MethodPut,
+			Uri:                "/system/functions",
+			ResponseStatusCode: http.StatusAccepted,
+		},
+	})
+	defer s.Close()
+
+	gatewayAddress := getGatewayURL(s.URL, defaultGateway, "", "")
+	for _, image := range []string{"figlet:0.1.0", "figlet:0.2.0"} {
+		if _, err := history.Record(gatewayAddress, proxy.DeployFunctionSpec{FunctionName: "figlet", Image: image}, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var err error
+	stdOut := test.CaptureStdout(func() {
+		faasCmd.SetArgs([]string{
+			"rollback",
+			"figlet",
+			"--gateway=" + s.URL,
+			"--to-revision=1",
+		})
+		err = faasCmd.Execute()
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !strings.Contains(stdOut, "Rolling back figlet to revision 1: figlet:0.1.0") {
+		t.Fatalf("output is not as expected:\n%s", stdOut)
+	}
+
+	revisions, err := history.List(gatewayAddress, "", "figlet")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(revisions) != 3 || revisions[2].Spec.Image != "figlet:0.1.0" {
+		t.Fatalf("want rollback to be recorded as revision 3, got %v", revisions)
+	}
+}
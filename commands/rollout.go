@@ -0,0 +1,145 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/openfaas/faas-cli/proxy"
+	"github.com/openfaas/faas-provider/types"
+	"github.com/openfaas/go-sdk/stack"
+)
+
+// rolloutInterval is the time between checks on a function during a rollout
+var rolloutInterval = time.Second * 1
+
+// rolloutOptions controls whether deploy waits for a new version of a
+// function to become ready, and what to do when it does not.
+type rolloutOptions struct {
+	wait     bool
+	rollback bool
+	timeout  time.Duration
+}
+
+// waitForRollout polls the gateway until the function reports the expected
+// image and all of its replicas are available, or the timeout is reached.
+func waitForRollout(ctx context.Context, client *proxy.Client, name, namespace, image string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var lastErr error
+	for {
+		status, err := client.GetFunctionInfo(ctx, name, namespace)
+		if err != nil {
+			lastErr = err
+		} else if status.Image == image && status.AvailableReplicas > 0 && status.AvailableReplicas >= status.Replicas {
+			return nil
+		} else {
+			lastErr = fmt.Errorf("image: %s, available replicas: %d/%d", status.Image, status.AvailableReplicas, status.Replicas)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("function %s not ready after: %s (%s)", name, timeout, lastErr)
+		case <-time.After(rolloutInterval):
+		}
+	}
+}
+
+// captureFunction returns the current status of a function, so that it can
+// be restored if a rollout fails. Nil is returned if the function does not
+// exist yet.
+func captureFunction(ctx context.Context, client *proxy.Client, name, namespace string) *types.FunctionStatus {
+	status, err := client.GetFunctionInfo(ctx, name, namespace)
+	if err != nil {
+		return nil
+	}
+	return &status
+}
+
+// specFromFunctionStatus builds a deploy spec which re-applies a function
+// as it was reported by the gateway.
+func specFromFunctionStatus(status types.FunctionStatus, namespace string, tlsInsecure bool, token string) *proxy.DeployFunctionSpec {
+	envVars := map[string]string{}
+	for k, v := range status.EnvVars {
+		if k != "fprocess" {
+			envVars[k] = v
+		}
+	}
+
+	labels := map[string]string{}
+	if status.Labels != nil {
+		labels = *status.Labels
+	}
+
+	annotations := map[string]string{}
+	if status.Annotations != nil {
+		annotations = *status.Annotations
+	}
+
+	if len(status.Namespace) > 0 {
+		namespace = status.Namespace
+	}
+
+	spec := &proxy.DeployFunctionSpec{
+		FProcess:               status.EnvProcess,
+		FunctionName:           status.Name,
+		Image:                  status.Image,
+		EnvVars:                envVars,
+		Constraints:            status.Constraints,
+		Update:                 true,
+		Secrets:                status.Secrets,
+		Labels:                 labels,
+		Annotations:            annotations,
+		ReadOnlyRootFilesystem: status.ReadOnlyRootFilesystem,
+		TLSInsecure:            tlsInsecure,
+		Token:                  token,
+		Namespace:              namespace,
+	}
+
+	if status.Limits != nil {
+		spec.FunctionResourceRequest.Limits = &stack.FunctionResources{
+			CPU:    status.Limits.CPU,
+			Memory: status.Limits.Memory,
+		}
+	}
+	if status.Requests != nil {
+		spec.FunctionResourceRequest.Requests = &stack.FunctionResources{
+			CPU:    status.Requests.CPU,
+			Memory: status.Requests.Memory,
+		}
+	}
+
+	return spec
+}
+
+// awaitRollout waits for a deployed function to become ready and, when
+// requested, re-applies the previous version if it does not.
+func awaitRollout(ctx context.Context, client *proxy.Client, spec *proxy.DeployFunctionSpec, previous *types.FunctionStatus, opts rolloutOptions) error {
+	fmt.Printf("Waiting for rollout of: %s.\n", spec.FunctionName)
+
+	err := waitForRollout(ctx, client, spec.FunctionName, spec.Namespace, spec.Image, opts.timeout)
+	if err == nil {
+		fmt.Printf("Function %s is ready.\n", spec.FunctionName)
+		return nil
+	}
+
+	if !opts.rollback {
+		return err
+	}
+
+	if previous == nil {
+		return fmt.Errorf("%s, no previous version to roll back to", err)
+	}
+
+	fmt.Printf("Rolling back %s to: %s.\n", spec.FunctionName, previous.Image)
+	rollbackSpec := specFromFunctionStatus(*previous, spec.Namespace, spec.TLSInsecure, spec.Token)
+	if statusCode := client.DeployFunction(ctx, rollbackSpec); badStatusCode(statusCode) {
+		return fmt.Errorf("%s, rollback failed with status code: %d", err, statusCode)
+	}
+
+	return fmt.Errorf("%s, rolled back to: %s", err, previous.Image)
+}
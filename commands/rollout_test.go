@@ -0,0 +1,122 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openfaas/faas-cli/proxy"
+	"github.com/openfaas/faas-cli/test"
+	"github.com/openfaas/faas-provider/types"
+)
+
+func newRolloutTestClient(t *testing.T, url string) *proxy.Client {
+	cliAuth, err := proxy.NewCLIAuth("", url)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := proxy.NewClient(cliAuth, url, nil, &commandTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func Test_waitForRollout_Ready(t *testing.T) {
+	defer func(d time.Duration) { rolloutInterval = d }(rolloutInterval)
+	rolloutInterval = time.Millisecond
+
+	s := test.MockHttpServer(t, []test.Request{
+		{
+			Method:       http.MethodGet,
+			ResponseBody: types.FunctionStatus{Name: "figlet", Image: "figlet:0.1.0", Replicas: 1, AvailableReplicas: 1},
+		},
+		{
+			Method:       http.MethodGet,
+			ResponseBody: types.FunctionStatus{Name: "figlet", Image: "figlet:0.2.0", Replicas: 1},
+		},
+		{
+			Method:       http.MethodGet,
+			ResponseBody: types.FunctionStatus{Name: "figlet", Image: "figlet:0.2.0", Replicas: 1, AvailableReplicas: 1},
+		},
+	})
+	defer s.Close()
+
+	client := newRolloutTestClient(t, s.URL)
+
+	if err := waitForRollout(context.Background(), client, "figlet", "", "figlet:0.2.0", time.Second*5); err != nil {
+		t.Fatalf("want rollout to complete, got: %s", err)
+	}
+}
+
+func Test_awaitRollout_RollsBack(t *testing.T) {
+	defer func(d time.Duration) { rolloutInterval = d }(rolloutInterval)
+	rolloutInterval = time.Millisecond * 20
+
+	s := test.MockHttpServer(t, []test.Request{
+		{
+			Method:       http.MethodGet,
+			ResponseBody: types.FunctionStatus{Name: "figlet", Image: "figlet:0.2.0", Replicas: 1},
+		},
+		{
+			Method:             http.MethodPut,
+			Uri:                "/system/functions",
+			ResponseStatusCode: http.StatusAccepted,
+		},
+	})
+	defer s.Close()
+
+	client := newRolloutTestClient(t, s.URL)
+
+	spec := &proxy.DeployFunctionSpec{FunctionName: "figlet", Image: "figlet:0.2.0"}
+	previous := &types.FunctionStatus{Name: "figlet", Image: "figlet:0.1.0"}
+	opts := rolloutOptions{wait: true, rollback: true, timeout: time.Millisecond * 10}
+
+	var err error
+	test.CaptureStdout(func() {
+		err = awaitRollout(context.Background(), client, spec, previous, opts)
+	})
+
+	if err == nil {
+		t.Fatal("want an error when the rollout does not complete")
+	}
+
+	if !strings.Contains(err.Error(), "rolled back to: figlet:0.1.0") {
+		t.Fatalf("want error to mention the rollback, got: %s", err)
+	}
+}
+
+func Test_specFromFunctionStatus(t *testing.T) {
+	status := types.FunctionStatus{
+		Name:       "figlet",
+		Image:      "figlet:0.1.0",
+		Namespace:  "staging",
+		EnvProcess: "figlet",
+		EnvVars:    map[string]string{"fprocess": "figlet", "mode": "fast"},
+		Limits:     &types.FunctionResources{Memory: "128Mi"},
+	}
+
+	spec := specFromFunctionStatus(status, "", false, "")
+
+	if spec.Namespace != "staging" {
+		t.Errorf("want namespace staging, got %s", spec.Namespace)
+	}
+
+	if _, ok := spec.EnvVars["fprocess"]; ok {
+		t.Errorf("want fprocess to be removed from the environment")
+	}
+
+	if spec.FunctionResourceRequest.Limits == nil || spec.FunctionResourceRequest.Limits.Memory != "128Mi" {
+		t.Errorf("want memory limit of 128Mi, got %v", spec.FunctionResourceRequest.Limits)
+	}
+
+	if !spec.Update {
+		t.Errorf("want spec to perform an update")
+	}
+}
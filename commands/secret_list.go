@@ -7,9 +7,11 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"text/tabwriter"
 
+	"github.com/openfaas/faas-cli/output"
 	"github.com/openfaas/faas-cli/proxy"
 	types "github.com/openfaas/faas-provider/types"
 	"github.com/spf13/cobra"
@@ -22,7 +24,8 @@ var secretListCmd = &cobra.Command{
 	Short:   "List all secrets",
 	Long:    `List all secrets`,
 	Example: `faas-cli secret list
-faas-cli secret list --gateway=http://127.0.0.1:8080`,
+faas-cli secret list --gateway=http://127.0.0.1:8080
+faas-cli secret list -o name`,
 	RunE:    runSecretList,
 	PreRunE: preRunSecretListCmd,
 }
@@ -32,6 +35,7 @@ func init() {
 	secretListCmd.Flags().BoolVar(&tlsInsecure, "tls-no-verify", false, "Disable TLS validation")
 	secretListCmd.Flags().StringVarP(&token, "token", "k", "", "Pass a JWT token to use instead of basic auth")
 	secretListCmd.Flags().StringVarP(&functionNamespace, "namespace", "n", "", "Namespace of the function")
+	secretListCmd.Flags().StringVarP(&outputFormat, "output", "o", "", output.FlagUsage)
 
 	secretCmd.AddCommand(secretListCmd)
 }
@@ -41,6 +45,11 @@ func preRunSecretListCmd(cmd *cobra.Command, args []string) error {
 }
 
 func runSecretList(cmd *cobra.Command, args []string) error {
+	printer, err := output.NewPrinter(outputFormat)
+	if err != nil {
+		return err
+	}
+
 	var gatewayAddress string
 	gatewayAddress = getGatewayURL(gateway, defaultGateway, "", os.Getenv(openFaaSURLEnvironment))
 
@@ -63,24 +72,44 @@ func runSecretList(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if len(secrets) == 0 {
+	if len(secrets) == 0 && printer.IsTable() {
 		fmt.Printf("No secrets found.\n")
 		return nil
 	}
 
-	fmt.Printf("%s", renderSecretList(secrets))
+	if secrets == nil {
+		secrets = []types.Secret{}
+	}
+
+	names := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		names = append(names, secret.Name)
+	}
 
-	return nil
+	return printer.Print(os.Stdout, output.Result{
+		Object: secrets,
+		Names:  names,
+		Table: func(w io.Writer, wide bool) {
+			fmt.Fprint(w, renderSecretList(secrets, wide))
+		},
+	})
 }
 
-func renderSecretList(secrets []types.Secret) string {
+func renderSecretList(secrets []types.Secret, wide bool) string {
 	var b bytes.Buffer
 	w := tabwriter.NewWriter(&b, 0, 0, 1, ' ', 0)
 	fmt.Fprintln(w)
-	fmt.Fprintln(w, "NAME")
 
-	for _, secret := range secrets {
-		fmt.Fprintf(w, "%s\n", secret.Name)
+	if wide {
+		fmt.Fprintln(w, "NAME\tNAMESPACE")
+		for _, secret := range secrets {
+			fmt.Fprintf(w, "%s\t%s\n", secret.Name, secret.Namespace)
+		}
+	} else {
+		fmt.Fprintln(w, "NAME")
+		for _, secret := range secrets {
+			fmt.Fprintf(w, "%s\n", secret.Name)
+		}
 	}
 
 	fmt.Fprintln(w)
@@ -0,0 +1,56 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/openfaas/faas-cli/test"
+	types "github.com/openfaas/faas-provider/types"
+)
+
+func Test_secretList_Output(t *testing.T) {
+	cases := []struct {
+		name    string
+		output  string
+		secrets []types.Secret
+		want    string
+	}{
+		{name: "table", output: "", secrets: []types.Secret{{Name: "db-password"}}, want: "\nNAME\ndb-password\n\n"},
+		{name: "wide", output: "wide", secrets: []types.Secret{{Name: "db-password", Namespace: "dev"}}, want: "\nNAME        NAMESPACE\ndb-password dev\n\n"},
+		{name: "yaml", output: "yaml", secrets: []types.Secret{{Name: "db-password", Namespace: "dev"}}, want: "- name: db-password\n  namespace: dev\n"},
+		{name: "empty table", output: "", secrets: []types.Secret{}, want: "No secrets found.\n"},
+		{name: "empty json", output: "json", secrets: []types.Secret{}, want: "[]\n"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := test.MockHttpServer(t, []test.Request{
+				{
+					Method:             http.MethodGet,
+					Uri:                "/system/secrets",
+					ResponseStatusCode: http.StatusOK,
+					ResponseBody:       tc.secrets,
+				},
+			})
+			defer s.Close()
+
+			resetForTest()
+			defer func() { outputFormat = "" }()
+
+			var err error
+			stdOut := test.CaptureStdout(func() {
+				faasCmd.SetArgs([]string{"secret", "list", "--gateway=" + s.URL, "--output=" + tc.output})
+				err = faasCmd.Execute()
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stdOut != tc.want {
+				t.Fatalf("want %q, got: %q", tc.want, stdOut)
+			}
+		})
+	}
+}
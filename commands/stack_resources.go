@@ -0,0 +1,161 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/openfaas/faas-cli/proxy"
+	"github.com/openfaas/faas-cli/schema"
+	"github.com/openfaas/faas-provider/types"
+	"github.com/openfaas/go-sdk"
+)
+
+// reconcileStackResources creates or updates the namespaces and secrets
+// declared in the stack file, so that they exist before functions are deployed.
+func reconcileStackResources(ctx context.Context, sdkClient *sdk.Client, proxyClient *proxy.Client, resources *schema.StackResources, dryRun bool) error {
+	if len(resources.Namespaces) > 0 {
+		if err := reconcileNamespaces(ctx, sdkClient, resources.Namespaces, dryRun); err != nil {
+			return err
+		}
+	}
+
+	if len(resources.Secrets) > 0 {
+		if err := reconcileSecrets(ctx, proxyClient, resources.Secrets, dryRun); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func reconcileNamespaces(ctx context.Context, client *sdk.Client, namespaces map[string]schema.StackNamespace, dryRun bool) error {
+	existing, err := client.GetNamespaces(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to list namespaces: %w", err)
+	}
+
+	exists := map[string]bool{}
+	for _, ns := range existing {
+		exists[ns] = true
+	}
+
+	names := make([]string, 0, len(namespaces))
+	for name := range namespaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		req := types.FunctionNamespace{
+			Name:        name,
+			Labels:      namespaces[name].Labels,
+			Annotations: namespaces[name].Annotations,
+		}
+
+		if exists[name] {
+			fmt.Printf("Updating namespace: %s\n", name)
+			if dryRun {
+				continue
+			}
+			if _, err := client.UpdateNamespace(ctx, req); err != nil {
+				return fmt.Errorf("unable to update namespace %s: %w", name, err)
+			}
+		} else {
+			fmt.Printf("Creating namespace: %s\n", name)
+			if dryRun {
+				continue
+			}
+			if _, err := client.CreateNamespace(ctx, req); err != nil {
+				return fmt.Errorf("unable to create namespace %s: %w", name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func reconcileSecrets(ctx context.Context, client *proxy.Client, secrets map[string]schema.StackSecret, dryRun bool) error {
+	names := make([]string, 0, len(secrets))
+	for name := range secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Read every value up front so that a missing secret is reported before
+	// anything is changed on the gateway.
+	values := map[string]types.Secret{}
+	for _, name := range names {
+		if isValid, err := validateSecretName(name); !isValid {
+			return err
+		}
+
+		value, err := readStackSecret(name, secrets[name])
+		if err != nil {
+			return err
+		}
+		values[name] = value
+	}
+
+	for _, name := range names {
+		secret := values[name]
+		secret.Name = name
+		secret.Namespace = getNamespace(functionNamespace, secrets[name].Namespace)
+
+		fmt.Printf("Applying secret: %s\n", functionRef(secret.Name, secret.Namespace))
+		if dryRun {
+			continue
+		}
+
+		status, output := client.CreateSecret(ctx, secret)
+		if status == http.StatusConflict {
+			status, output = client.UpdateSecret(ctx, secret)
+		}
+
+		if status != http.StatusOK && status != http.StatusCreated && status != http.StatusAccepted {
+			return fmt.Errorf("unable to apply secret %s: %s", secret.Name, strings.TrimSpace(output))
+		}
+	}
+
+	return nil
+}
+
+// readStackSecret reads the value of a secret declared in the stack file.
+// Values are sent as they are, without trimming whitespace, in the same way as
+// "secret create --from-file", so that keys and binary files are kept intact.
+func readStackSecret(name string, secret schema.StackSecret) (types.Secret, error) {
+	switch {
+	case len(secret.FromEnv) > 0:
+		value, ok := os.LookupEnv(secret.FromEnv)
+		if !ok || len(strings.TrimSpace(value)) == 0 {
+			return types.Secret{}, fmt.Errorf("secret %s: environment variable %s is not set", name, secret.FromEnv)
+		}
+		return types.Secret{Value: value}, nil
+
+	default:
+		secretPath := secret.FromFile
+		if len(secretPath) == 0 {
+			secretPath = filepath.Join(localSecretsDir, name)
+		}
+
+		data, err := os.ReadFile(secretPath)
+		if err != nil {
+			return types.Secret{}, fmt.Errorf("secret %s: unable to read value: %w", name, err)
+		}
+
+		if len(bytes.TrimSpace(data)) == 0 {
+			return types.Secret{}, fmt.Errorf("secret %s: %s is empty", name, secretPath)
+		}
+
+		// Value is retained for backwards compatibility, as with secret create
+		return types.Secret{RawValue: data, Value: string(data)}, nil
+	}
+}
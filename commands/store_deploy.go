@@ -146,7 +146,7 @@ func runStoreDeploy(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	statusCode, err := deployImage(context.Background(),
+	statusCode, _, err := deployImage(context.Background(),
 		proxyClient,
 		imageName,
 		item.Fprocess,
@@ -6,10 +6,12 @@ package commands
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"text/tabwriter"
 
+	"github.com/openfaas/faas-cli/output"
 	storeV2 "github.com/openfaas/faas-cli/schema/store/v2"
 	"github.com/spf13/cobra"
 )
@@ -17,6 +19,7 @@ import (
 func init() {
 	// Setup flags used by store command
 	storeListCmd.Flags().BoolVarP(&verbose, "verbose", "v", true, "Enable verbose output to see the full description of each function in the store")
+	storeListCmd.Flags().StringVarP(&outputFormat, "output", "o", "", output.FlagUsage)
 
 	storeCmd.AddCommand(storeListCmd)
 }
@@ -27,11 +30,17 @@ var storeListCmd = &cobra.Command{
 	Short:   "List available OpenFaaS functions in a store",
 	Example: `  faas-cli store list
   faas-cli store list --verbose
-  faas-cli store list --url https://host:port/store.json`,
+  faas-cli store list --url https://host:port/store.json
+  faas-cli store list -o wide`,
 	RunE: runStoreList,
 }
 
 func runStoreList(cmd *cobra.Command, args []string) error {
+	printer, err := output.NewPrinter(outputFormat)
+	if err != nil {
+		return err
+	}
+
 	targetPlatform := getTargetPlatform(platformValue)
 
 	// Support priority order override.
@@ -51,15 +60,32 @@ func runStoreList(cmd *cobra.Command, args []string) error {
 
 	filteredFunctions := filterStoreList(storeList, targetPlatform)
 
-	if len(filteredFunctions) == 0 {
+	if len(filteredFunctions) == 0 && printer.IsTable() {
 		availablePlatforms := getStorePlatforms(storeList)
 		fmt.Printf("No functions found in the store for platform '%s', try one of the following: %s\n", targetPlatform, strings.Join(availablePlatforms, ", "))
 		return nil
 	}
 
-	fmt.Print(storeRenderItems(filteredFunctions))
+	if filteredFunctions == nil {
+		filteredFunctions = []storeV2.StoreFunction{}
+	}
+
+	names := make([]string, 0, len(filteredFunctions))
+	for _, item := range filteredFunctions {
+		names = append(names, item.Name)
+	}
 
-	return nil
+	return printer.Print(os.Stdout, output.Result{
+		Object: filteredFunctions,
+		Names:  names,
+		Table: func(w io.Writer, wide bool) {
+			if wide {
+				fmt.Fprint(w, storeRenderWideItems(filteredFunctions, targetPlatform))
+				return
+			}
+			fmt.Fprint(w, storeRenderItems(filteredFunctions))
+		},
+	})
 }
 
 func storeRenderItems(items []storeV2.StoreFunction) string {
@@ -82,6 +108,28 @@ func storeRenderItems(items []storeV2.StoreFunction) string {
 	return b.String()
 }
 
+// storeRenderWideItems adds the image for the platform and the repository
+// of each function
+func storeRenderWideItems(items []storeV2.StoreFunction, platform string) string {
+	var b bytes.Buffer
+	w := tabwriter.NewWriter(&b, 0, 0, 1, ' ', 0)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "FUNCTION\tAUTHOR\tIMAGE\tREPOSITORY\tDESCRIPTION")
+
+	for _, item := range items {
+		author := item.Author
+		if author == "" {
+			author = "unknown"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", item.Name, author, item.GetImageName(platform), item.RepoURL, storeRenderDescription(item.Title))
+	}
+
+	fmt.Fprintln(w)
+	w.Flush()
+	return b.String()
+}
+
 func storeRenderDescription(descr string) string {
 	if !verbose && len(descr) > maxDescriptionLen {
 		return descr[0:maxDescriptionLen-3] + "..."
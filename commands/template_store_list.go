@@ -17,6 +17,7 @@ import (
 	"text/tabwriter"
 	"time"
 
+	"github.com/openfaas/faas-cli/output"
 	"github.com/spf13/cobra"
 )
 
@@ -39,6 +40,7 @@ func init() {
 	templateStoreListCmd.Flags().StringVarP(&inputPlatform, "platform", "p", mainPlatform, "Shows the platform if the output is verbose")
 	templateStoreListCmd.Flags().BoolVarP(&recommended, "recommended", "r", false, "Shows only recommended templates")
 	templateStoreListCmd.Flags().BoolVarP(&official, "official", "o", false, "Shows only official templates")
+	templateStoreListCmd.Flags().StringVar(&outputFormat, "output", "", output.FlagUsage)
 
 	templateStoreCmd.AddCommand(templateStoreListCmd)
 }
@@ -69,11 +71,19 @@ official list maintained by the OpenFaaS community is used. You can override thi
 
   # Filter by platform for arm64 only
   faas-cli template store list --platform arm64 
+
+  # Print the templates as JSON, -o is short for --official
+  faas-cli template store list --output json
 `,
 	RunE: runTemplateStoreList,
 }
 
 func runTemplateStoreList(cmd *cobra.Command, args []string) error {
+	printer, err := output.NewPrinter(outputFormat)
+	if err != nil {
+		return err
+	}
+
 	envTemplateRepoStore := os.Getenv(templateStoreURLEnvironment)
 	storeURL := getTemplateStoreURL(templateStoreURL, envTemplateRepoStore, DefaultTemplatesStore)
 
@@ -99,11 +109,23 @@ func runTemplateStoreList(cmd *cobra.Command, args []string) error {
 		list = templatesInfo
 	}
 
-	formattedOutput := formatTemplatesOutput(list, verbose, inputPlatform)
+	filtered := filterTemplate(list, inputPlatform)
+	if filtered == nil {
+		filtered = []TemplateInfo{}
+	}
 
-	fmt.Fprintf(cmd.OutOrStdout(), "%s", formattedOutput)
+	names := make([]string, 0, len(filtered))
+	for _, template := range filtered {
+		names = append(names, template.TemplateName)
+	}
 
-	return nil
+	return printer.Print(cmd.OutOrStdout(), output.Result{
+		Object: filtered,
+		Names:  names,
+		Table: func(w io.Writer, wide bool) {
+			fmt.Fprintf(w, "%s", formatTemplatesOutput(list, verbose || wide, inputPlatform))
+		},
+	})
 }
 
 func getTemplateInfo(repository string) ([]TemplateInfo, error) {
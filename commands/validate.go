@@ -4,9 +4,417 @@
 package commands
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/crane"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/openfaas/faas-cli/proxy"
+	"github.com/openfaas/faas-cli/schema"
+	"github.com/openfaas/faas-provider/types"
+	"github.com/openfaas/go-sdk/stack"
+	"github.com/spf13/cobra"
+)
+
+const (
+	findingError   = "error"
+	findingWarning = "warning"
 )
 
+// validFunctionQuantity matches the quantities accepted by Kubernetes for
+// CPU and memory, i.e. 100m, 0.5, 128Mi or 1G.
+var validFunctionQuantity = regexp.MustCompile(`^([0-9]+(\.[0-9]*)?|\.[0-9]+)(([KMGTPE]i)|[numkMGTPE]|[eE][+-]?[0-9]+)?$`)
+
+// imageExists reports whether an image tag can be found in its registry
+var imageExists = func(ctx context.Context, image string) (bool, error) {
+	_, err := crane.Head(image, crane.WithContext(ctx))
+	if err != nil {
+		var terr *transport.Error
+		if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+var validateOutput string
+
+func init() {
+	validateCmd.Flags().StringVarP(&gateway, "gateway", "g", defaultGateway, "Gateway URL starting with http(s)://, for the online checks")
+	validateCmd.Flags().StringVarP(&functionNamespace, "namespace", "n", "", "Namespace of the function")
+	validateCmd.Flags().BoolVar(&tlsInsecure, "tls-no-verify", false, "Disable TLS validation")
+	validateCmd.Flags().StringVarP(&token, "token", "k", "", "Pass a JWT token to use instead of basic auth")
+	validateCmd.Flags().BoolVar(&envsubst, "envsubst", true, "Substitute environment variables in stack.yaml file")
+	validateCmd.Flags().StringVar(&regex, "regex", "", "Regex to match with function names in YAML file")
+	validateCmd.Flags().StringVar(&filter, "filter", "", "Wildcard to match with function names in YAML file")
+	validateCmd.Flags().StringVarP(&validateOutput, "output", "o", "", `Output format for the findings, set to "json" for CI`)
+
+	faasCmd.AddCommand(validateCmd)
+}
+
+var validateCmd = &cobra.Command{
+	Use:   `validate -f YAML_FILE [--gateway GATEWAY_URL] [--output json]`,
+	Short: "Check a stack file for problems before building or deploying",
+	Long: `Checks a stack file for problems before anything is built or deployed and
+reports every finding at once.
+
+Offline checks cover function names, image names, handler paths, language
+templates, environment files and CPU/memory quantities.
+
+When a gateway is given with --gateway, or set to something other than the
+default by a context, the stack file or OPENFAAS_URL, the namespaces and
+secrets used by each function are checked against the gateway, and the image
+tags against their registries. The "online" field of the JSON output records
+whether these checks were run.
+
+The command exits with a non-zero status when any errors are found.`,
+	Example: `  faas-cli validate
+  faas-cli validate -f stack.yaml --filter "*gif*"
+  faas-cli validate -f stack.yaml --gateway http://127.0.0.1:8080
+  faas-cli validate -f stack.yaml -o json`,
+	PreRunE: preRunValidate,
+	RunE:    runValidate,
+}
+
+// validationFinding is a single problem found in a stack file
+type validationFinding struct {
+	Level    string `json:"level"`
+	Function string `json:"function,omitempty"`
+	Check    string `json:"check"`
+	Message  string `json:"message"`
+}
+
+func preRunValidate(cmd *cobra.Command, args []string) error {
+	if validateOutput != "" && validateOutput != "json" {
+		return fmt.Errorf(`the --output flag must be "json" when given`)
+	}
+	return nil
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	services, err := stack.ParseYAMLFile(yamlFile, regex, filter, envsubst)
+	if err != nil {
+		return err
+	}
+
+	findings := validateStack(*services)
+
+	gatewayAddress := getGatewayURL(gateway, defaultGateway, services.Provider.GatewayURL, os.Getenv(openFaaSURLEnvironment))
+	online := runOnlineChecks(gatewayAddress)
+
+	if online {
+		resources, err := schema.ParseStackResources(yamlFile, envsubst)
+		if err != nil {
+			return err
+		}
+
+		cliAuth, err := proxy.NewCLIAuth(token, gatewayAddress)
+		if err != nil {
+			return err
+		}
+		transport := GetDefaultCLITransport(tlsInsecure, &commandTimeout)
+		proxyClient, err := proxy.NewClient(cliAuth, gatewayAddress, transport, &commandTimeout)
+		if err != nil {
+			return err
+		}
+
+		listNamespaces := func(ctx context.Context) ([]string, error) {
+			sdkClient, err := newSDKClient(gatewayAddress)
+			if err != nil {
+				return nil, err
+			}
+			return sdkClient.GetNamespaces(ctx)
+		}
+
+		ctx := context.Background()
+		findings = append(findings, validateStackOnline(ctx, proxyClient, listNamespaces, *services, resources)...)
+	}
+
+	sortFindings(findings)
+
+	if validateOutput == "json" {
+		if err := printFindingsJSON(os.Stdout, findings, online); err != nil {
+			return err
+		}
+	} else {
+		printFindings(os.Stdout, findings)
+		if !online {
+			fmt.Println("Online checks were skipped, give a gateway to check namespaces, secrets and images.")
+		}
+	}
+
+	if errorCount := countFindings(findings, findingError); errorCount > 0 {
+		return fmt.Errorf("validation failed with %d error(s)", errorCount)
+	}
+
+	return nil
+}
+
+// runOnlineChecks reports whether to check the stack against the gateway and
+// registries, which is done when --gateway is given, or when a context, the
+// stack file or OPENFAAS_URL sets a gateway other than the default.
+func runOnlineChecks(gatewayAddress string) bool {
+	return gatewayFlagSet || gatewayAddress != defaultGateway
+}
+
+// validateStack runs the checks which do not need a gateway or registry
+func validateStack(services stack.Services) []validationFinding {
+	findings := []validationFinding{}
+
+	for name, function := range services.Functions {
+		add := func(level, check, format string, a ...interface{}) {
+			findings = append(findings, validationFinding{
+				Level:    level,
+				Function: name,
+				Check:    check,
+				Message:  fmt.Sprintf(format, a...),
+			})
+		}
+
+		if err := validateFunctionName(name); err != nil {
+			add(findingError, "name", "%s", err)
+		}
+
+		if len(function.Image) == 0 {
+			add(findingError, "image", "no image given")
+		} else if len(validateImages(map[string]stack.Function{name: function})) > 0 {
+			add(findingError, "image", "image %s must be prefixed with a registry or owner, i.e. ghcr.io/owner/%s", function.Image, function.Image)
+		}
+
+		if !function.SkipBuild {
+			if len(function.Language) == 0 {
+				add(findingError, "lang", "no lang given, set one or use skip_build: true")
+			} else if !stack.IsValidTemplate(function.Language) {
+				add(findingError, "lang", "template %s not found in ./template, run \"faas-cli template pull stack\"", function.Language)
+			}
+
+			if len(function.Handler) == 0 {
+				add(findingError, "handler", "no handler given")
+			} else if info, err := os.Stat(function.Handler); err != nil {
+				add(findingError, "handler", "handler %s does not exist", function.Handler)
+			} else if !info.IsDir() {
+				add(findingError, "handler", "handler %s is not a folder", function.Handler)
+			}
+		}
+
+		for _, file := range function.EnvironmentFile {
+			if _, err := readFiles([]string{file}); err != nil {
+				add(findingError, "environment_file", "unable to read %s: %s", file, err)
+			}
+		}
+
+		for kind, resources := range map[string]*stack.FunctionResources{"limits": function.Limits, "requests": function.Requests} {
+			if resources == nil {
+				continue
+			}
+			if len(resources.CPU) > 0 && !validFunctionQuantity.MatchString(resources.CPU) {
+				add(findingError, kind, "invalid CPU quantity: %s", resources.CPU)
+			}
+			if len(resources.Memory) > 0 && !validFunctionQuantity.MatchString(resources.Memory) {
+				add(findingError, kind, "invalid memory quantity: %s", resources.Memory)
+			}
+		}
+	}
+
+	return findings
+}
+
+// validateStackOnline checks that the namespaces and secrets used by each
+// function exist, and that each image can be found in its registry.
+// Namespaces and secrets declared in the stack file itself are created by
+// deploy, so are not reported.
+func validateStackOnline(ctx context.Context, client *proxy.Client, listNamespaces func(context.Context) ([]string, error), services stack.Services, resources *schema.StackResources) []validationFinding {
+	findings := []validationFinding{}
+
+	byNamespace := map[string][]string{}
+	for name, function := range services.Functions {
+		namespace := getNamespace(functionNamespace, function.Namespace)
+		byNamespace[namespace] = append(byNamespace[namespace], name)
+	}
+
+	// existing is left nil when namespaces are not used, or can't be listed
+	var existing map[string]bool
+	if _, ok := byNamespace[""]; !ok || len(byNamespace) > 1 {
+		list, err := listNamespaces(ctx)
+		if err != nil {
+			findings = append(findings, validationFinding{
+				Level:   findingWarning,
+				Check:   "namespace",
+				Message: fmt.Sprintf("unable to list namespaces: %s", err),
+			})
+		} else {
+			existing = map[string]bool{}
+			for _, namespace := range list {
+				existing[namespace] = true
+			}
+		}
+	}
+
+	for namespace, names := range byNamespace {
+		_, declared := resources.Namespaces[namespace]
+		missing := existing != nil && len(namespace) > 0 && !existing[namespace]
+
+		if missing && !declared {
+			for _, name := range names {
+				findings = append(findings, validationFinding{
+					Level:    findingError,
+					Function: name,
+					Check:    "namespace",
+					Message:  fmt.Sprintf("namespace %s does not exist", namespace),
+				})
+			}
+			continue
+		}
+
+		var list []types.Secret
+		if !missing {
+			var err error
+			list, err = client.GetSecretList(ctx, namespace)
+			if err != nil {
+				findings = append(findings, validationFinding{
+					Level:   findingWarning,
+					Check:   "secrets",
+					Message: fmt.Sprintf("unable to list secrets in %s: %s", namespaceOrDefault(namespace), err),
+				})
+				continue
+			}
+		}
+
+		secrets := map[string]bool{}
+		for _, secret := range list {
+			secrets[secret.Name] = true
+		}
+		for name, secret := range resources.Secrets {
+			if getNamespace(functionNamespace, secret.Namespace) == namespace {
+				secrets[name] = true
+			}
+		}
+
+		for _, name := range names {
+			for _, secret := range services.Functions[name].Secrets {
+				if !secrets[secret] {
+					findings = append(findings, validationFinding{
+						Level:    findingError,
+						Function: name,
+						Check:    "secrets",
+						Message:  fmt.Sprintf("secret %s does not exist in %s", secret, namespaceOrDefault(namespace)),
+					})
+				}
+			}
+		}
+	}
+
+	for name, function := range services.Functions {
+		if len(function.Image) == 0 {
+			continue
+		}
+
+		found, err := imageExists(ctx, function.Image)
+		if err != nil {
+			findings = append(findings, validationFinding{
+				Level:    findingWarning,
+				Function: name,
+				Check:    "image",
+				Message:  fmt.Sprintf("unable to check image %s: %s", function.Image, err),
+			})
+			continue
+		}
+
+		if !found {
+			// Images built from the stack file are expected to be missing
+			// until they have been pushed.
+			level := findingWarning
+			if function.SkipBuild {
+				level = findingError
+			}
+			findings = append(findings, validationFinding{
+				Level:    level,
+				Function: name,
+				Check:    "image",
+				Message:  fmt.Sprintf("image %s was not found in the registry", function.Image),
+			})
+		}
+	}
+
+	return findings
+}
+
+func namespaceOrDefault(namespace string) string {
+	if len(namespace) == 0 {
+		return "the default namespace"
+	}
+	return namespace
+}
+
+func sortFindings(findings []validationFinding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Function != findings[j].Function {
+			return findings[i].Function < findings[j].Function
+		}
+		return findings[i].Check < findings[j].Check
+	})
+}
+
+func countFindings(findings []validationFinding, level string) int {
+	count := 0
+	for _, f := range findings {
+		if f.Level == level {
+			count++
+		}
+	}
+	return count
+}
+
+func printFindings(w io.Writer, findings []validationFinding) {
+	if len(findings) == 0 {
+		fmt.Fprintln(w, "No problems found.")
+		return
+	}
+
+	for _, f := range findings {
+		subject := f.Function
+		if len(subject) == 0 {
+			subject = "stack"
+		}
+		fmt.Fprintf(w, "%s\t%s (%s): %s\n", strings.ToUpper(f.Level), subject, f.Check, f.Message)
+	}
+
+	fmt.Fprintf(w, "\n%d error(s), %d warning(s)\n", countFindings(findings, findingError), countFindings(findings, findingWarning))
+}
+
+func printFindingsJSON(w io.Writer, findings []validationFinding, online bool) error {
+	out := struct {
+		Valid    bool                `json:"valid"`
+		Online   bool                `json:"online"`
+		Errors   int                 `json:"errors"`
+		Warnings int                 `json:"warnings"`
+		Findings []validationFinding `json:"findings"`
+	}{
+		Valid:    countFindings(findings, findingError) == 0,
+		Online:   online,
+		Errors:   countFindings(findings, findingError),
+		Warnings: countFindings(findings, findingWarning),
+		Findings: findings,
+	}
+
+	b, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, string(b))
+	return nil
+}
+
 func validateLanguageFlag(language string) (string, error) {
 	var err error
 
@@ -1,7 +1,20 @@
 package commands
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
+
+	"github.com/openfaas/faas-cli/config"
+	"github.com/openfaas/faas-cli/proxy"
+	"github.com/openfaas/faas-cli/schema"
+	"github.com/openfaas/faas-cli/test"
+	"github.com/openfaas/go-sdk/stack"
 )
 
 func TestValidateLanguageFlag_dockerfile(t *testing.T) {
@@ -19,3 +32,205 @@ func TestValidateLanguageFlag_Dockerfile(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func Test_validateStack_Offline(t *testing.T) {
+	dir := t.TempDir()
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.MkdirAll(filepath.Join("template", "python3"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join("template", "python3", "template.yml"), []byte("language: python3\nfprocess: python3 index.py\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll("figlet", 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	services := stack.Services{
+		Functions: map[string]stack.Function{
+			"figlet": {
+				Language: "python3",
+				Handler:  "./figlet",
+				Image:    "ghcr.io/openfaas/figlet:latest",
+				Limits:   &stack.FunctionResources{CPU: "100m", Memory: "128Mi"},
+			},
+			"Bad_Name": {
+				Language:        "golang",
+				Handler:         "./missing",
+				Image:           "bad-name:latest",
+				EnvironmentFile: []string{"missing.yml"},
+				Requests:        &stack.FunctionResources{CPU: "lots", Memory: "1G"},
+			},
+			"nodeinfo": {
+				SkipBuild: true,
+				Image:     "ghcr.io/openfaas/nodeinfo:latest",
+			},
+		},
+	}
+
+	findings := validateStack(services)
+	sortFindings(findings)
+
+	got := []string{}
+	for _, f := range findings {
+		got = append(got, f.Function+"/"+f.Check)
+	}
+
+	want := []string{
+		"Bad_Name/environment_file",
+		"Bad_Name/handler",
+		"Bad_Name/image",
+		"Bad_Name/lang",
+		"Bad_Name/name",
+		"Bad_Name/requests",
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want findings:\n%v\ngot:\n%v", want, got)
+	}
+}
+
+func Test_validateStackOnline(t *testing.T) {
+	s := test.MockHttpServer(t, []test.Request{
+		{
+			Method:             http.MethodGet,
+			Uri:                "/system/secrets?namespace=staging-fn",
+			ResponseStatusCode: http.StatusOK,
+			ResponseBody:       `[{"name":"api-key"}]`,
+		},
+	})
+	defer s.Close()
+
+	cliAuth, err := proxy.NewCLIAuth("", s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := proxy.NewClient(cliAuth, s.URL, nil, &commandTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(fn func(context.Context, string) (bool, error)) { imageExists = fn }(imageExists)
+	imageExists = func(ctx context.Context, image string) (bool, error) {
+		return image == "ghcr.io/openfaas/figlet:latest", nil
+	}
+
+	listNamespaces := func(ctx context.Context) ([]string, error) {
+		return []string{"openfaas-fn", "staging-fn"}, nil
+	}
+
+	services := stack.Services{
+		Functions: map[string]stack.Function{
+			"figlet": {
+				Namespace: "staging-fn",
+				Image:     "ghcr.io/openfaas/figlet:latest",
+				Secrets:   []string{"api-key", "db-password", "webhook-token"},
+			},
+			"nodeinfo": {
+				Namespace: "missing-fn",
+				SkipBuild: true,
+				Image:     "ghcr.io/openfaas/nodeinfo:missing",
+			},
+		},
+	}
+	resources := &schema.StackResources{
+		Secrets: map[string]schema.StackSecret{
+			"webhook-token": {Namespace: "staging-fn"},
+		},
+	}
+
+	findings := validateStackOnline(context.Background(), client, listNamespaces, services, resources)
+	sortFindings(findings)
+
+	got := []string{}
+	for _, f := range findings {
+		got = append(got, f.Level+" "+f.Function+": "+f.Message)
+	}
+
+	want := []string{
+		"error figlet: secret db-password does not exist in staging-fn",
+		"error nodeinfo: image ghcr.io/openfaas/nodeinfo:missing was not found in the registry",
+		"error nodeinfo: namespace missing-fn does not exist",
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want findings:\n%v\ngot:\n%v", want, got)
+	}
+}
+
+func Test_printFindingsJSON(t *testing.T) {
+	findings := []validationFinding{
+		{Level: findingError, Function: "figlet", Check: "image", Message: "image figlet must be prefixed"},
+		{Level: findingWarning, Function: "figlet", Check: "image", Message: "unable to check image"},
+	}
+
+	var b bytes.Buffer
+	if err := printFindingsJSON(&b, findings, true); err != nil {
+		t.Fatal(err)
+	}
+
+	var out struct {
+		Valid    bool                `json:"valid"`
+		Online   bool                `json:"online"`
+		Errors   int                 `json:"errors"`
+		Warnings int                 `json:"warnings"`
+		Findings []validationFinding `json:"findings"`
+	}
+	if err := json.Unmarshal(b.Bytes(), &out); err != nil {
+		t.Fatalf("output is not valid JSON: %s", err)
+	}
+
+	if out.Valid || !out.Online || out.Errors != 1 || out.Warnings != 1 || len(out.Findings) != 2 {
+		t.Errorf("unexpected output: %s", b.String())
+	}
+}
+
+func Test_runOnlineChecks(t *testing.T) {
+	defer func(fn func() (config.Context, bool)) { activeContext = fn }(activeContext)
+	activeContext = func() (config.Context, bool) { return config.Context{}, false }
+
+	if runOnlineChecks(getGatewayURL(defaultGateway, defaultGateway, "", "")) {
+		t.Errorf("want offline checks only when no gateway is set")
+	}
+
+	if !runOnlineChecks(getGatewayURL(defaultGateway, defaultGateway, "", "http://env:8080")) {
+		t.Errorf("want online checks when OPENFAAS_URL is set")
+	}
+
+	if !runOnlineChecks(getGatewayURL(defaultGateway, defaultGateway, "http://yaml:8080", "")) {
+		t.Errorf("want online checks when the stack file sets a gateway")
+	}
+
+	activeContext = func() (config.Context, bool) {
+		return config.Context{Name: "staging", Gateway: "https://staging.example.com"}, true
+	}
+	if !runOnlineChecks(getGatewayURL(defaultGateway, defaultGateway, "", "")) {
+		t.Errorf("want online checks when a context sets a gateway")
+	}
+
+	gatewayFlagSet = true
+	defer func() { gatewayFlagSet = false }()
+	activeContext = func() (config.Context, bool) { return config.Context{}, false }
+	if !runOnlineChecks(getGatewayURL(defaultGateway, defaultGateway, "", "")) {
+		t.Errorf("want online checks when --gateway is given")
+	}
+}
+
+func Test_validFunctionQuantity(t *testing.T) {
+	for _, q := range []string{"100m", "0.5", "1", "128Mi", "1G", "1e3", ".5"} {
+		if !validFunctionQuantity.MatchString(q) {
+			t.Errorf("want %q to be valid", q)
+		}
+	}
+
+	for _, q := range []string{"lots", "128MB", "-1", "1.2.3", "m"} {
+		if validFunctionQuantity.MatchString(q) {
+			t.Errorf("want %q to be invalid", q)
+		}
+	}
+}
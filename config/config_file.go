@@ -47,8 +47,19 @@ const (
 
 // ConfigFile for OpenFaaS CLI exclusively.
 type ConfigFile struct {
-	AuthConfigs []AuthConfig `yaml:"auths"`
-	FilePath    string       `yaml:"-"`
+	AuthConfigs    []AuthConfig `yaml:"auths"`
+	Contexts       []Context    `yaml:"contexts,omitempty"`
+	CurrentContext string       `yaml:"current-context,omitempty"`
+
+	// CredsStore is the name of the credential helper used to store the
+	// token of each auth entry, instead of saving it in this file.
+	CredsStore string `yaml:"credsStore,omitempty"`
+
+	// CredHelpers sets the credential helper to use for a gateway URL, and
+	// takes priority over CredsStore.
+	CredHelpers map[string]string `yaml:"credHelpers,omitempty"`
+
+	FilePath string `yaml:"-"`
 }
 
 type AuthConfig struct {
@@ -201,6 +212,12 @@ func (configFile *ConfigFile) load() error {
 	if len(conf.AuthConfigs) > 0 {
 		configFile.AuthConfigs = conf.AuthConfigs
 	}
+	if len(conf.Contexts) > 0 {
+		configFile.Contexts = conf.Contexts
+	}
+	configFile.CurrentContext = conf.CurrentContext
+	configFile.CredsStore = conf.CredsStore
+	configFile.CredHelpers = conf.CredHelpers
 	return nil
 }
 
@@ -249,6 +266,16 @@ func UpdateAuthConfig(authConfig AuthConfig) error {
 		return err
 	}
 
+	// The token and any refresh token are kept by the credential helper, so
+	// only the gateway, auth type and other options are saved in the file.
+	if helper := cfg.credentialHelper(gateway); len(helper) > 0 {
+		if err := storeCredentials(helper, authConfig); err != nil {
+			return err
+		}
+		authConfig.Token = ""
+		authConfig.Options = withoutOption(authConfig.Options, RefreshTokenOption)
+	}
+
 	index := -1
 	for i, v := range cfg.AuthConfigs {
 		if gateway == v.Gateway {
@@ -294,14 +321,76 @@ func LookupAuthConfig(gateway string) (AuthConfig, error) {
 
 	for _, v := range cfg.AuthConfigs {
 		if gateway == v.Gateway {
-			authConfig = v
-			return authConfig, nil
+			return cfg.withCredentials(v)
+		}
+	}
+
+	// A context may point at the auth entry of another gateway URL, i.e.
+	// when the same gateway is reached through a port-forward.
+	for _, c := range cfg.Contexts {
+		if c.Gateway != gateway || len(c.Auth) == 0 || c.Auth == gateway {
+			continue
+		}
+		for _, v := range cfg.AuthConfigs {
+			if c.Auth == v.Gateway {
+				return cfg.withCredentials(v)
+			}
 		}
 	}
 
 	return authConfig, &AuthConfigNotFoundError{Gateway: gateway}
 }
 
+// withCredentials reads the token for an auth entry from its credential
+// helper, when one is configured.
+func (configFile *ConfigFile) withCredentials(authConfig AuthConfig) (AuthConfig, error) {
+	helper := configFile.credentialHelper(authConfig.Gateway)
+	if len(helper) == 0 || len(authConfig.Token) > 0 {
+		return authConfig, nil
+	}
+
+	if err := getCredentials(helper, &authConfig); err != nil {
+		if errors.Is(err, errCredentialsNotFound) {
+			return authConfig, &AuthConfigNotFoundError{Gateway: authConfig.Gateway}
+		}
+		return authConfig, err
+	}
+
+	return authConfig, nil
+}
+
+// ListAuthConfigs returns every saved auth entry. When a credential helper
+// can't return the token for an entry, the entry is returned without it.
+func ListAuthConfigs() ([]AuthConfig, error) {
+	if !fileExists() {
+		return nil, nil
+	}
+
+	configPath, err := EnsureFile()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := New(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.load(); err != nil {
+		return nil, err
+	}
+
+	authConfigs := make([]AuthConfig, 0, len(cfg.AuthConfigs))
+	for _, v := range cfg.AuthConfigs {
+		if withToken, err := cfg.withCredentials(v); err == nil {
+			v = withToken
+		}
+		authConfigs = append(authConfigs, v)
+	}
+
+	return authConfigs, nil
+}
+
 // RemoveAuthConfig deletes the username and password for a given gateway
 func RemoveAuthConfig(gateway string) error {
 	if !fileExists() {
@@ -331,6 +420,15 @@ func RemoveAuthConfig(gateway string) error {
 	}
 
 	if index > -1 {
+		if helper := cfg.credentialHelper(gateway); len(helper) > 0 {
+			if err := eraseCredentials(helper, gateway); err != nil {
+				return err
+			}
+			if err := eraseCredentials(helper, gateway+refreshTokenSuffix); err != nil {
+				return err
+			}
+		}
+
 		cfg.AuthConfigs = removeAuthByIndex(cfg.AuthConfigs, index)
 		if err := cfg.save(); err != nil {
 			return err
@@ -0,0 +1,175 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Context is a named set of defaults for a gateway, so that the gateway URL,
+// namespace and TLS settings do not need to be given on every command.
+type Context struct {
+	Name        string `yaml:"name"`
+	Gateway     string `yaml:"gateway"`
+	Namespace   string `yaml:"namespace,omitempty"`
+	TLSInsecure bool   `yaml:"tls_insecure,omitempty"`
+
+	// Auth is the gateway URL of the auth entry to use for this context,
+	// when empty the entry for Gateway is used.
+	Auth string `yaml:"auth,omitempty"`
+}
+
+type ContextNotFoundError struct {
+	Name string
+}
+
+func (e *ContextNotFoundError) Error() string {
+	return fmt.Sprintf("no context found with name %s", e.Name)
+}
+
+func loadConfigFile() (*ConfigFile, error) {
+	configPath, err := EnsureFile()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := New(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.load(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// UpdateContext creates or updates a context by name
+func UpdateContext(context Context) error {
+	if len(context.Name) == 0 {
+		return fmt.Errorf("context name required")
+	}
+
+	if _, err := url.ParseRequestURI(context.Gateway); err != nil || len(context.Gateway) < 1 {
+		return fmt.Errorf("invalid gateway URL")
+	}
+
+	cfg, err := loadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	index := -1
+	for i, v := range cfg.Contexts {
+		if context.Name == v.Name {
+			index = i
+			break
+		}
+	}
+
+	if index == -1 {
+		cfg.Contexts = append(cfg.Contexts, context)
+	} else {
+		cfg.Contexts[index] = context
+	}
+
+	return cfg.save()
+}
+
+// LookupContext returns the context with the given name, or the current
+// context when name is empty. The bool is false when there is no current
+// context.
+func LookupContext(name string) (Context, bool, error) {
+	if !fileExists() {
+		if len(name) > 0 {
+			return Context{}, false, &ContextNotFoundError{Name: name}
+		}
+		return Context{}, false, nil
+	}
+
+	cfg, err := loadConfigFile()
+	if err != nil {
+		return Context{}, false, err
+	}
+
+	if len(name) == 0 {
+		name = cfg.CurrentContext
+		if len(name) == 0 {
+			return Context{}, false, nil
+		}
+	}
+
+	for _, v := range cfg.Contexts {
+		if v.Name == name {
+			return v, true, nil
+		}
+	}
+
+	return Context{}, false, &ContextNotFoundError{Name: name}
+}
+
+// ListContexts returns all contexts and the name of the current context
+func ListContexts() ([]Context, string, error) {
+	if !fileExists() {
+		return nil, "", nil
+	}
+
+	cfg, err := loadConfigFile()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return cfg.Contexts, cfg.CurrentContext, nil
+}
+
+// SetCurrentContext makes the named context the current context
+func SetCurrentContext(name string) error {
+	cfg, err := loadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	for _, v := range cfg.Contexts {
+		if v.Name == name {
+			cfg.CurrentContext = name
+			return cfg.save()
+		}
+	}
+
+	return &ContextNotFoundError{Name: name}
+}
+
+// RemoveContext deletes the named context, and unsets the current context if
+// it was the one removed.
+func RemoveContext(name string) error {
+	if !fileExists() {
+		return ErrConfigNotFound
+	}
+
+	cfg, err := loadConfigFile()
+	if err != nil {
+		return err
+	}
+
+	index := -1
+	for i, v := range cfg.Contexts {
+		if v.Name == name {
+			index = i
+			break
+		}
+	}
+
+	if index == -1 {
+		return &ContextNotFoundError{Name: name}
+	}
+
+	cfg.Contexts = append(cfg.Contexts[:index], cfg.Contexts[index+1:]...)
+	if cfg.CurrentContext == name {
+		cfg.CurrentContext = ""
+	}
+
+	return cfg.save()
+}
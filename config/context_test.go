@@ -0,0 +1,139 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package config
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_LookupContext_NoConfigFile(t *testing.T) {
+	t.Setenv(ConfigLocationEnv, t.TempDir())
+
+	_, ok, err := LookupContext("")
+	if err != nil {
+		t.Fatalf("want no error without a current context, got: %s", err)
+	}
+	if ok {
+		t.Errorf("want no current context")
+	}
+
+	var notFound *ContextNotFoundError
+	if _, _, err := LookupContext("staging"); !errors.As(err, &notFound) {
+		t.Errorf("want ContextNotFoundError, got: %v", err)
+	}
+}
+
+func Test_UpdateContext_AndSetCurrent(t *testing.T) {
+	t.Setenv(ConfigLocationEnv, t.TempDir())
+
+	if err := UpdateAuthConfig(AuthConfig{Gateway: "http://openfaas.test", Auth: BasicAuthType, Token: EncodeAuth("admin", "pass")}); err != nil {
+		t.Fatal(err)
+	}
+
+	staging := Context{Name: "staging", Gateway: "https://staging.test", Namespace: "staging-fn", TLSInsecure: true}
+	if err := UpdateContext(staging); err != nil {
+		t.Fatal(err)
+	}
+	if err := UpdateContext(Context{Name: "local", Gateway: "http://openfaas.test"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetCurrentContext("staging"); err != nil {
+		t.Fatal(err)
+	}
+
+	got, ok, err := LookupContext("")
+	if err != nil || !ok {
+		t.Fatalf("want current context, got ok: %t err: %v", ok, err)
+	}
+	if got != staging {
+		t.Errorf("want %+v, got %+v", staging, got)
+	}
+
+	// Updating an auth entry must keep the contexts
+	if err := UpdateAuthConfig(AuthConfig{Gateway: "http://openfaas.test", Auth: BasicAuthType, Token: EncodeAuth("admin", "new")}); err != nil {
+		t.Fatal(err)
+	}
+
+	contexts, current, err := ListContexts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contexts) != 2 || current != "staging" {
+		t.Errorf("want 2 contexts with staging current, got %d with %q", len(contexts), current)
+	}
+
+	staging.Namespace = "openfaas-fn"
+	if err := UpdateContext(staging); err != nil {
+		t.Fatal(err)
+	}
+	if got, _, _ := LookupContext("staging"); got.Namespace != "openfaas-fn" {
+		t.Errorf("want namespace to be updated, got: %q", got.Namespace)
+	}
+}
+
+func Test_UpdateContext_Invalid(t *testing.T) {
+	t.Setenv(ConfigLocationEnv, t.TempDir())
+
+	if err := UpdateContext(Context{Gateway: "http://openfaas.test"}); err == nil {
+		t.Errorf("want error for a context without a name")
+	}
+
+	if err := UpdateContext(Context{Name: "staging", Gateway: "openfaas"}); err == nil {
+		t.Errorf("want error for an invalid gateway URL")
+	}
+}
+
+func Test_SetCurrentContext_NotFound(t *testing.T) {
+	t.Setenv(ConfigLocationEnv, t.TempDir())
+
+	var notFound *ContextNotFoundError
+	if err := SetCurrentContext("staging"); !errors.As(err, &notFound) {
+		t.Errorf("want ContextNotFoundError, got: %v", err)
+	}
+}
+
+func Test_RemoveContext_UnsetsCurrent(t *testing.T) {
+	t.Setenv(ConfigLocationEnv, t.TempDir())
+
+	if err := UpdateContext(Context{Name: "staging", Gateway: "https://staging.test"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetCurrentContext("staging"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RemoveContext("staging"); err != nil {
+		t.Fatal(err)
+	}
+
+	contexts, current, err := ListContexts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contexts) != 0 || current != "" {
+		t.Errorf("want no contexts and no current context, got %d with %q", len(contexts), current)
+	}
+}
+
+func Test_LookupAuthConfig_ViaContext(t *testing.T) {
+	t.Setenv(ConfigLocationEnv, t.TempDir())
+
+	token := EncodeAuth("admin", "pass")
+	if err := UpdateAuthConfig(AuthConfig{Gateway: "https://prod.test", Auth: BasicAuthType, Token: token}); err != nil {
+		t.Fatal(err)
+	}
+	if err := UpdateContext(Context{Name: "tunnel", Gateway: "http://127.0.0.1:31112", Auth: "https://prod.test"}); err != nil {
+		t.Fatal(err)
+	}
+
+	authConfig, err := LookupAuthConfig("http://127.0.0.1:31112")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if authConfig.Token != token {
+		t.Errorf("want the auth entry of https://prod.test, got: %+v", authConfig)
+	}
+}
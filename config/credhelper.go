@@ -0,0 +1,200 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// CredentialHelperPrefix is prepended to the name of a credential helper to
+// find its binary on the PATH, i.e. "pass" runs openfaas-credential-pass.
+const CredentialHelperPrefix = "openfaas-credential-"
+
+// tokenUsername is the username stored with a helper for bearer tokens,
+// matching the convention of the docker credential helpers.
+const tokenUsername = "<token>"
+
+// RefreshTokenOption is the auth entry option which holds the refresh token
+// of an OAuth2 login. It is kept by the credential helper when one is
+// configured, rather than in the config file.
+const RefreshTokenOption = "refresh_token"
+
+// refreshTokenSuffix is added to the gateway URL to store a refresh token
+// with a helper, apart from the access token stored for the gateway.
+const refreshTokenSuffix = "#" + RefreshTokenOption
+
+// errCredentialsNotFound is returned by "get" when a helper has no
+// credentials for a server.
+var errCredentialsNotFound = errors.New("credentials not found in native keychain")
+
+// helperCredentials is the message exchanged with a credential helper, using
+// the docker-credential-helpers protocol.
+type helperCredentials struct {
+	ServerURL string `json:"ServerURL"`
+	Username  string `json:"Username"`
+	Secret    string `json:"Secret"`
+}
+
+// runCredentialHelper runs a helper action, writing input to its stdin and
+// returning its stdout.
+var runCredentialHelper = func(helper, action string, input []byte) ([]byte, error) {
+	cmd := exec.Command(CredentialHelperPrefix+helper, action)
+	cmd.Stdin = bytes.NewReader(input)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stdout.String() + " " + stderr.String())
+		if strings.Contains(message, errCredentialsNotFound.Error()) {
+			return nil, errCredentialsNotFound
+		}
+
+		var execErr *exec.Error
+		if errors.As(err, &execErr) {
+			return nil, fmt.Errorf("credential helper %s%s not found: %w", CredentialHelperPrefix, helper, err)
+		}
+
+		return nil, fmt.Errorf("credential helper %s%s %s failed: %s", CredentialHelperPrefix, helper, action, message)
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// credentialHelper returns the helper configured for a gateway, or the
+// default credsStore when there is no helper for the gateway.
+func (configFile *ConfigFile) credentialHelper(gateway string) string {
+	if helper, ok := configFile.CredHelpers[gateway]; ok {
+		return helper
+	}
+	return configFile.CredsStore
+}
+
+// storeCredentials saves the token of an auth entry with a helper, along
+// with the refresh token of an OAuth2 entry.
+func storeCredentials(helper string, authConfig AuthConfig) error {
+	creds := helperCredentials{
+		ServerURL: authConfig.Gateway,
+		Username:  tokenUsername,
+		Secret:    authConfig.Token,
+	}
+
+	if authConfig.Auth == BasicAuthType {
+		username, password, err := DecodeAuth(authConfig.Token)
+		if err != nil {
+			return err
+		}
+		creds.Username = username
+		creds.Secret = password
+	}
+
+	if err := storeHelperCredentials(helper, creds); err != nil {
+		return err
+	}
+
+	if authConfig.Auth != Oauth2AuthType {
+		return nil
+	}
+
+	refreshToken := getOption(authConfig.Options, RefreshTokenOption)
+	if len(refreshToken) == 0 {
+		return eraseCredentials(helper, authConfig.Gateway+refreshTokenSuffix)
+	}
+
+	return storeHelperCredentials(helper, helperCredentials{
+		ServerURL: authConfig.Gateway + refreshTokenSuffix,
+		Username:  tokenUsername,
+		Secret:    refreshToken,
+	})
+}
+
+func storeHelperCredentials(helper string, creds helperCredentials) error {
+	input, err := json.Marshal(creds)
+	if err != nil {
+		return err
+	}
+
+	_, err = runCredentialHelper(helper, "store", input)
+	return err
+}
+
+// getCredentials sets the token of an auth entry from a helper, and the
+// refresh token of an OAuth2 entry when the helper has one.
+func getCredentials(helper string, authConfig *AuthConfig) error {
+	creds, err := getHelperCredentials(helper, authConfig.Gateway)
+	if err != nil {
+		return err
+	}
+
+	if authConfig.Auth == BasicAuthType {
+		authConfig.Token = EncodeAuth(creds.Username, creds.Secret)
+	} else {
+		authConfig.Token = creds.Secret
+	}
+
+	if authConfig.Auth != Oauth2AuthType || len(getOption(authConfig.Options, RefreshTokenOption)) > 0 {
+		return nil
+	}
+
+	refresh, err := getHelperCredentials(helper, authConfig.Gateway+refreshTokenSuffix)
+	if errors.Is(err, errCredentialsNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	authConfig.Options = append(authConfig.Options, Option{Name: RefreshTokenOption, Value: refresh.Secret})
+	return nil
+}
+
+func getHelperCredentials(helper, serverURL string) (helperCredentials, error) {
+	creds := helperCredentials{}
+
+	output, err := runCredentialHelper(helper, "get", []byte(serverURL))
+	if err != nil {
+		return creds, err
+	}
+
+	if err := json.Unmarshal(output, &creds); err != nil {
+		return creds, fmt.Errorf("unable to parse output of credential helper %s%s: %w", CredentialHelperPrefix, helper, err)
+	}
+
+	return creds, nil
+}
+
+// eraseCredentials removes the credentials for a server URL from a helper
+func eraseCredentials(helper, serverURL string) error {
+	_, err := runCredentialHelper(helper, "erase", []byte(serverURL))
+	if errors.Is(err, errCredentialsNotFound) {
+		return nil
+	}
+	return err
+}
+
+// getOption returns the value of a named option, or an empty string
+func getOption(options []Option, name string) string {
+	for _, o := range options {
+		if o.Name == name {
+			return o.Value
+		}
+	}
+	return ""
+}
+
+// withoutOption returns a copy of options without the named option
+func withoutOption(options []Option, name string) []Option {
+	var kept []Option
+	for _, o := range options {
+		if o.Name != name {
+			kept = append(kept, o)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,257 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package config
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// fakeHelperEnv makes the test binary act as a credential helper which keeps
+// credentials in the folder named by the variable.
+const fakeHelperEnv = "FAAS_CLI_FAKE_CREDENTIAL_HELPER"
+
+func TestMain(m *testing.M) {
+	if dir := os.Getenv(fakeHelperEnv); len(dir) > 0 && len(os.Args) == 2 {
+		os.Exit(fakeCredentialHelper(dir, os.Args[1]))
+	}
+	os.Exit(m.Run())
+}
+
+func fakeCredentialHelper(dir, action string) int {
+	input, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+
+	credsPath := func(serverURL string) string {
+		return filepath.Join(dir, hex.EncodeToString([]byte(strings.TrimSpace(serverURL))))
+	}
+
+	switch action {
+	case "store":
+		creds := helperCredentials{}
+		if err := json.Unmarshal(input, &creds); err != nil {
+			fmt.Println(err)
+			return 1
+		}
+		if err := os.WriteFile(credsPath(creds.ServerURL), input, 0600); err != nil {
+			fmt.Println(err)
+			return 1
+		}
+	case "get":
+		data, err := os.ReadFile(credsPath(string(input)))
+		if err != nil {
+			fmt.Println(errCredentialsNotFound.Error())
+			return 1
+		}
+		os.Stdout.Write(data)
+	case "erase":
+		if err := os.Remove(credsPath(string(input))); err != nil {
+			fmt.Println(errCredentialsNotFound.Error())
+			return 1
+		}
+	default:
+		fmt.Printf("unknown action: %s\n", action)
+		return 1
+	}
+
+	return 0
+}
+
+// installFakeHelper puts the fake helper on the PATH as
+// openfaas-credential-fake and returns the folder it stores credentials in.
+func installFakeHelper(t *testing.T) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake credential helper needs symlinks")
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	binDir := t.TempDir()
+	if err := os.Symlink(executable, filepath.Join(binDir, CredentialHelperPrefix+"fake")); err != nil {
+		t.Fatal(err)
+	}
+
+	storeDir := t.TempDir()
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv(fakeHelperEnv, storeDir)
+
+	return storeDir
+}
+
+func writeHelperConfig(t *testing.T, credsStore string, credHelpers map[string]string) string {
+	t.Setenv(ConfigLocationEnv, t.TempDir())
+
+	configPath, err := EnsureFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := New(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.CredsStore = credsStore
+	cfg.CredHelpers = credHelpers
+
+	if err := cfg.save(); err != nil {
+		t.Fatal(err)
+	}
+
+	return configPath
+}
+
+func Test_CredsStore_StoreLookupRemove(t *testing.T) {
+	storeDir := installFakeHelper(t)
+	configPath := writeHelperConfig(t, "fake", nil)
+
+	gateway := "http://openfaas.test"
+	token := EncodeAuth("admin", "s3cr3t")
+
+	if err := UpdateAuthConfig(AuthConfig{Gateway: gateway, Auth: BasicAuthType, Token: token}); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), token) {
+		t.Errorf("want the token to be kept out of the config file, got:\n%s", data)
+	}
+
+	stored := helperCredentials{}
+	raw, err := os.ReadFile(filepath.Join(storeDir, hex.EncodeToString([]byte(gateway))))
+	if err != nil {
+		t.Fatalf("want credentials in the helper: %s", err)
+	}
+	if err := json.Unmarshal(raw, &stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored.Username != "admin" || stored.Secret != "s3cr3t" {
+		t.Errorf("want username and password in the helper, got: %+v", stored)
+	}
+
+	authConfig, err := LookupAuthConfig(gateway)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if authConfig.Token != token || authConfig.Auth != BasicAuthType {
+		t.Errorf("want token from the helper, got: %+v", authConfig)
+	}
+
+	if err := RemoveAuthConfig(gateway); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ := os.ReadDir(storeDir); len(entries) != 0 {
+		t.Errorf("want credentials erased from the helper, got %d entries", len(entries))
+	}
+}
+
+func Test_CredHelpers_PerGateway(t *testing.T) {
+	installFakeHelper(t)
+	configPath := writeHelperConfig(t, "", map[string]string{"https://prod.test": "fake"})
+
+	if err := UpdateAuthConfig(AuthConfig{Gateway: "https://prod.test", Auth: Oauth2AuthType, Token: "prod-token"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := UpdateAuthConfig(AuthConfig{Gateway: "http://dev.test", Auth: Oauth2AuthType, Token: "dev-token"}); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "prod-token") {
+		t.Errorf("want the prod token to be kept by the helper")
+	}
+	if !strings.Contains(string(data), "dev-token") {
+		t.Errorf("want the dev token to be saved in the file, as it has no helper")
+	}
+
+	authConfig, err := LookupAuthConfig("https://prod.test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if authConfig.Token != "prod-token" {
+		t.Errorf("want token from the helper, got: %q", authConfig.Token)
+	}
+}
+
+func Test_CredsStore_KeepsRefreshTokenOutOfFile(t *testing.T) {
+	storeDir := installFakeHelper(t)
+	configPath := writeHelperConfig(t, "fake", nil)
+
+	gateway := "http://openfaas.test"
+	options := []Option{
+		{Name: "client_id", Value: "faas-cli"},
+		{Name: RefreshTokenOption, Value: "refresh-token-1"},
+	}
+
+	if err := UpdateAuthConfig(AuthConfig{Gateway: gateway, Auth: Oauth2AuthType, Token: "access-token-1", Options: options}); err != nil {
+		t.Fatal(err)
+	}
+
+	if options[1].Value != "refresh-token-1" {
+		t.Errorf("want the options of the caller to be left alone, got: %v", options)
+	}
+
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"access-token-1", "refresh-token-1", "token:", RefreshTokenOption} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("want %q to be kept out of the config file, got:\n%s", secret, data)
+		}
+	}
+	if !strings.Contains(string(data), "faas-cli") {
+		t.Errorf("want other options to be saved in the config file, got:\n%s", data)
+	}
+
+	authConfig, err := LookupAuthConfig(gateway)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if authConfig.Token != "access-token-1" {
+		t.Errorf("want token from the helper, got: %q", authConfig.Token)
+	}
+	if got := getOption(authConfig.Options, RefreshTokenOption); got != "refresh-token-1" {
+		t.Errorf("want refresh token from the helper, got: %q", got)
+	}
+
+	if err := RemoveAuthConfig(gateway); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ := os.ReadDir(storeDir); len(entries) != 0 {
+		t.Errorf("want credentials erased from the helper, got %d entries", len(entries))
+	}
+}
+
+func Test_CredsStore_HelperMissing(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	writeHelperConfig(t, "missing", nil)
+
+	err := UpdateAuthConfig(AuthConfig{Gateway: "http://openfaas.test", Auth: BasicAuthType, Token: EncodeAuth("admin", "pass")})
+	if err == nil {
+		t.Fatalf("want error when the helper binary is not installed")
+	}
+
+	if !strings.Contains(err.Error(), CredentialHelperPrefix+"missing") {
+		t.Errorf("want error to name the helper, got: %s", err)
+	}
+}
@@ -0,0 +1,559 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+// Package har records HTTP traffic to a HAR 1.2 archive, which can be opened
+// in a browser's developer tools or attached to a bug report.
+package har
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptrace"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Redacted replaces sensitive values in the archive
+const Redacted = "[REDACTED]"
+
+// maxBodySize is the most of each request and response body kept in the
+// archive, bodies such as streamed logs are truncated beyond it.
+const maxBodySize = 1024 * 1024
+
+// saveInterval is the least time between two writes of the archive while
+// requests are being recorded, Close writes the entries added since.
+const saveInterval = time.Second
+
+// Archive is the top-level object of a HAR file
+type Archive struct {
+	Log Log `json:"log"`
+}
+
+// Log holds the entries of a HAR file
+type Log struct {
+	Version string  `json:"version"`
+	Creator Creator `json:"creator"`
+	Entries []Entry `json:"entries"`
+}
+
+// Creator names the program which wrote the archive
+type Creator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// Entry is a single request and its response
+type Entry struct {
+	StartedDateTime time.Time `json:"startedDateTime"`
+	Time            float64   `json:"time"`
+	Request         Request   `json:"request"`
+	Response        Response  `json:"response"`
+	Cache           struct{}  `json:"cache"`
+	Timings         Timings   `json:"timings"`
+	Comment         string    `json:"comment,omitempty"`
+}
+
+// Request is the request of an Entry
+type Request struct {
+	Method      string      `json:"method"`
+	URL         string      `json:"url"`
+	HTTPVersion string      `json:"httpVersion"`
+	Cookies     []NameValue `json:"cookies"`
+	Headers     []NameValue `json:"headers"`
+	QueryString []NameValue `json:"queryString"`
+	PostData    *PostData   `json:"postData,omitempty"`
+	HeadersSize int         `json:"headersSize"`
+	BodySize    int64       `json:"bodySize"`
+}
+
+// Response is the response of an Entry, Status is zero when no response was
+// received.
+type Response struct {
+	Status      int         `json:"status"`
+	StatusText  string      `json:"statusText"`
+	HTTPVersion string      `json:"httpVersion"`
+	Cookies     []NameValue `json:"cookies"`
+	Headers     []NameValue `json:"headers"`
+	Content     Content     `json:"content"`
+	RedirectURL string      `json:"redirectURL"`
+	HeadersSize int         `json:"headersSize"`
+	BodySize    int64       `json:"bodySize"`
+}
+
+// NameValue is a header, cookie or query string parameter
+type NameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// PostData is the body of a request
+type PostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+// Content is the body of a response
+type Content struct {
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+// Timings break down the time taken by an Entry in milliseconds, -1 means
+// the phase did not happen, such as DNS for a reused connection.
+type Timings struct {
+	Blocked float64 `json:"blocked"`
+	DNS     float64 `json:"dns"`
+	Connect float64 `json:"connect"`
+	SSL     float64 `json:"ssl"`
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// Recorder collects entries and writes them to a file as they complete, at
+// most once per saveInterval, and then once more when it is closed.
+type Recorder struct {
+	path    string
+	creator Creator
+
+	mu      sync.Mutex
+	entries []Entry
+	saved   time.Time
+}
+
+// NewRecorder returns a Recorder which writes to path, the file is created
+// straight away so that a bad path is reported before any request is made.
+func NewRecorder(path, version string) (*Recorder, error) {
+	r := &Recorder{
+		path:    path,
+		creator: Creator{Name: "faas-cli", Version: version},
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.save(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Wrap returns a RoundTripper which records every request made through next,
+// or through http.DefaultTransport when next is nil.
+func (r *Recorder) Wrap(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &transport{recorder: r, next: next}
+}
+
+// Entries returns a copy of the entries recorded so far
+func (r *Recorder) Entries() []Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entries := make([]Entry, len(r.entries))
+	copy(entries, r.entries)
+	return entries
+}
+
+// Close writes every entry recorded so far to the file
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.save()
+}
+
+func (r *Recorder) add(entry Entry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries = append(r.entries, entry)
+	if time.Since(r.saved) < saveInterval {
+		return nil
+	}
+	return r.save()
+}
+
+// save rewrites the whole archive to a temporary file which then replaces
+// the file at path, so that the file is a valid archive even when the CLI
+// exits before the last response is read, or while it is being written.
+// r.mu must be held, so that an older copy of the entries is never written
+// over a newer one.
+func (r *Recorder) save() error {
+	entries := make([]Entry, len(r.entries))
+	copy(entries, r.entries)
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].StartedDateTime.Before(entries[j].StartedDateTime)
+	})
+
+	data, err := json.MarshalIndent(Archive{
+		Log: Log{Version: "1.2", Creator: r.creator, Entries: entries},
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(r.path), "."+filepath.Base(r.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), r.path); err != nil {
+		return err
+	}
+
+	r.saved = time.Now()
+	return nil
+}
+
+type transport struct {
+	recorder *Recorder
+	next     http.RoundTripper
+}
+
+// phases are the times reported by httptrace for a single request
+type phases struct {
+	mu sync.Mutex
+
+	getConn, gotConn         time.Time
+	dnsStart, dnsDone        time.Time
+	connectStart, connectEnd time.Time
+	tlsStart, tlsDone        time.Time
+	wroteRequest, firstByte  time.Time
+}
+
+func (p *phases) set(t *time.Time) {
+	p.mu.Lock()
+	if t.IsZero() {
+		*t = time.Now()
+	}
+	p.mu.Unlock()
+}
+
+func (p *phases) trace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		GetConn:              func(string) { p.set(&p.getConn) },
+		GotConn:              func(httptrace.GotConnInfo) { p.set(&p.gotConn) },
+		DNSStart:             func(httptrace.DNSStartInfo) { p.set(&p.dnsStart) },
+		DNSDone:              func(httptrace.DNSDoneInfo) { p.set(&p.dnsDone) },
+		ConnectStart:         func(string, string) { p.set(&p.connectStart) },
+		ConnectDone:          func(string, string, error) { p.set(&p.connectEnd) },
+		TLSHandshakeStart:    func() { p.set(&p.tlsStart) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { p.set(&p.tlsDone) },
+		WroteRequest:         func(httptrace.WroteRequestInfo) { p.set(&p.wroteRequest) },
+		GotFirstResponseByte: func() { p.set(&p.firstByte) },
+	}
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	started := time.Now()
+
+	entry := Entry{
+		StartedDateTime: started,
+		Request:         newRequest(req),
+	}
+
+	// The request is cloned, as a RoundTripper must not modify the one given
+	p := &phases{}
+	traced := req.Clone(httptrace.WithClientTrace(req.Context(), p.trace()))
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		traced.Body = io.NopCloser(bytes.NewReader(body))
+
+		entry.Request.BodySize = int64(len(body))
+		entry.Request.PostData = newPostData(req, body)
+	}
+	req = traced
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		entry.Comment = err.Error()
+		entry.Timings, entry.Time = p.timings(started, time.Now())
+		t.recorder.add(entry)
+		return nil, err
+	}
+
+	entry.Response = newResponse(res)
+	res.Body = &recordingBody{
+		ReadCloser: res.Body,
+		done: func(body []byte, size int64, truncated bool) {
+			entry.Response.BodySize = size
+			entry.Response.Content = newContent(res, body, size, truncated)
+			entry.Timings, entry.Time = p.timings(started, time.Now())
+			t.recorder.add(entry)
+		},
+	}
+
+	return res, nil
+}
+
+func (p *phases) timings(started, finished time.Time) (Timings, float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	timings := Timings{
+		Blocked: -1,
+		DNS:     between(p.dnsStart, p.dnsDone),
+		Connect: between(p.connectStart, p.connectEnd),
+		SSL:     between(p.tlsStart, p.tlsDone),
+		Send:    0,
+		Wait:    0,
+		Receive: 0,
+	}
+
+	// The connect time in a HAR file includes the TLS handshake
+	if timings.Connect >= 0 && timings.SSL >= 0 {
+		timings.Connect += timings.SSL
+	}
+
+	if !p.getConn.IsZero() && !p.gotConn.IsZero() {
+		blocked := between(p.getConn, p.gotConn)
+		for _, phase := range []float64{timings.DNS, timings.Connect} {
+			if phase > 0 {
+				blocked -= phase
+			}
+		}
+		timings.Blocked = max(blocked, 0)
+	}
+
+	if !p.gotConn.IsZero() && !p.wroteRequest.IsZero() {
+		timings.Send = between(p.gotConn, p.wroteRequest)
+	}
+	if !p.wroteRequest.IsZero() && !p.firstByte.IsZero() {
+		timings.Wait = between(p.wroteRequest, p.firstByte)
+	}
+	if !p.firstByte.IsZero() {
+		timings.Receive = between(p.firstByte, finished)
+	}
+
+	return timings, between(started, finished)
+}
+
+// between returns the milliseconds from start to end, or -1 when either is
+// not set.
+func between(start, end time.Time) float64 {
+	if start.IsZero() || end.IsZero() {
+		return -1
+	}
+	return float64(end.Sub(start).Microseconds()) / 1000
+}
+
+// recordingBody keeps a copy of a response body as it is read, and calls
+// done once, at the end of the body or when it is closed.
+type recordingBody struct {
+	io.ReadCloser
+
+	buf       bytes.Buffer
+	size      int64
+	truncated bool
+	once      sync.Once
+	done      func(body []byte, size int64, truncated bool)
+}
+
+func (b *recordingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.size += int64(n)
+		if room := maxBodySize - b.buf.Len(); room > 0 {
+			b.buf.Write(p[:min(n, room)])
+		}
+		if b.size > maxBodySize {
+			b.truncated = true
+		}
+	}
+
+	if err == io.EOF {
+		b.finish()
+	}
+	return n, err
+}
+
+func (b *recordingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.finish()
+	return err
+}
+
+func (b *recordingBody) finish() {
+	b.once.Do(func() {
+		b.done(b.buf.Bytes(), b.size, b.truncated)
+	})
+}
+
+func newRequest(req *http.Request) Request {
+	query := []NameValue{}
+	for name, values := range req.URL.Query() {
+		for _, value := range values {
+			query = append(query, NameValue{Name: name, Value: value})
+		}
+	}
+	sort.Slice(query, func(i, j int) bool { return query[i].Name < query[j].Name })
+
+	return Request{
+		Method:      req.Method,
+		URL:         req.URL.String(),
+		HTTPVersion: req.Proto,
+		Cookies:     []NameValue{},
+		Headers:     headers(req.Header),
+		QueryString: query,
+		HeadersSize: -1,
+	}
+}
+
+func newResponse(res *http.Response) Response {
+	return Response{
+		Status:      res.StatusCode,
+		StatusText:  strings.TrimPrefix(res.Status, strconv.Itoa(res.StatusCode)+" "),
+		HTTPVersion: res.Proto,
+		Cookies:     []NameValue{},
+		Headers:     headers(res.Header),
+		RedirectURL: res.Header.Get("Location"),
+		HeadersSize: -1,
+	}
+}
+
+func newPostData(req *http.Request, body []byte) *PostData {
+	postData := &PostData{
+		MimeType: req.Header.Get("Content-Type"),
+	}
+
+	if isSecretRequest(req) {
+		postData.Text = redactSecret(body)
+		return postData
+	}
+
+	text, encoded := bodyText(body, postData.MimeType)
+	postData.Text = text
+	if encoded {
+		postData.Comment = "base64 encoded"
+	}
+	return postData
+}
+
+func newContent(res *http.Response, body []byte, size int64, truncated bool) Content {
+	content := Content{
+		Size:     size,
+		MimeType: res.Header.Get("Content-Type"),
+	}
+
+	text, encoded := bodyText(body, content.MimeType)
+	content.Text = text
+	if encoded {
+		content.Encoding = "base64"
+	}
+	if truncated {
+		content.Comment = "truncated"
+	}
+	return content
+}
+
+// bodyText returns the body as text, or base64 encoded when it is not
+// valid UTF-8 or has a binary content type.
+func bodyText(body []byte, contentType string) (string, bool) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	binary := strings.HasPrefix(mediaType, "image/") ||
+		strings.HasPrefix(mediaType, "audio/") ||
+		strings.HasPrefix(mediaType, "video/") ||
+		mediaType == "application/octet-stream"
+
+	if binary || !utf8.Valid(body) {
+		return base64.StdEncoding.EncodeToString(body), true
+	}
+	return string(body), false
+}
+
+// sensitiveHeaders carry credentials, so their values are redacted
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+}
+
+func headers(header http.Header) []NameValue {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	list := []NameValue{}
+	for _, name := range names {
+		for _, value := range header[name] {
+			if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+				value = RedactAuthorization(value)
+			}
+			list = append(list, NameValue{Name: name, Value: value})
+		}
+	}
+	return list
+}
+
+// RedactAuthorization keeps the scheme of an Authorization header, so that it
+// is still clear whether basic auth or a bearer token was sent.
+func RedactAuthorization(value string) string {
+	scheme, _, ok := strings.Cut(value, " ")
+	if ok && (strings.EqualFold(scheme, "Basic") || strings.EqualFold(scheme, "Bearer")) {
+		return scheme + " " + Redacted
+	}
+	return Redacted
+}
+
+// isSecretRequest reports whether the request creates or updates a secret
+func isSecretRequest(req *http.Request) bool {
+	return (req.Method == http.MethodPost || req.Method == http.MethodPut) &&
+		strings.HasSuffix(strings.TrimRight(req.URL.Path, "/"), "/system/secrets")
+}
+
+// redactSecret removes the value from a secret's JSON payload, keeping its
+// name and namespace.
+func redactSecret(body []byte) string {
+	secret := map[string]interface{}{}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return Redacted
+	}
+
+	for _, field := range []string{"value", "rawValue"} {
+		if _, ok := secret[field]; ok {
+			secret[field] = Redacted
+		}
+	}
+
+	data, err := json.Marshal(secret)
+	if err != nil {
+		return Redacted
+	}
+	return string(data)
+}
@@ -0,0 +1,273 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package har
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func newTestRecorder(t *testing.T) (*Recorder, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "gateway.har")
+	recorder, err := NewRecorder(path, "0.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return recorder, path
+}
+
+func readArchive(t *testing.T, path string) Archive {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	archive := Archive{}
+	if err := json.Unmarshal(data, &archive); err != nil {
+		t.Fatalf("archive is not valid JSON: %s", err)
+	}
+	return archive
+}
+
+func Test_NewRecorder_WritesEmptyArchive(t *testing.T) {
+	_, path := newTestRecorder(t)
+
+	archive := readArchive(t, path)
+	if archive.Log.Version != "1.2" {
+		t.Fatalf("want version 1.2, got: %s", archive.Log.Version)
+	}
+	if archive.Log.Creator.Name != "faas-cli" || archive.Log.Creator.Version != "0.0.0" {
+		t.Fatalf("unexpected creator: %v", archive.Log.Creator)
+	}
+	if len(archive.Log.Entries) != 0 {
+		t.Fatalf("want no entries, got: %d", len(archive.Log.Entries))
+	}
+}
+
+func Test_Recorder_RecordsRequestAndResponse(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=abc")
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer s.Close()
+
+	recorder, path := newTestRecorder(t)
+	client := &http.Client{Transport: recorder.Wrap(nil)}
+
+	req, _ := http.NewRequest(http.MethodPost, s.URL+"/function/figlet?name=openfaas", strings.NewReader("hello"))
+	req.Header.Set("Authorization", "Bearer secret-token")
+	req.Header.Set("Content-Type", "text/plain")
+
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+
+	if string(body) != `{"status":"ok"}` {
+		t.Fatalf("the response body was changed: %q", string(body))
+	}
+
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	archive := readArchive(t, path)
+	if len(archive.Log.Entries) != 1 {
+		t.Fatalf("want 1 entry, got: %d", len(archive.Log.Entries))
+	}
+	entry := archive.Log.Entries[0]
+
+	if entry.Request.Method != http.MethodPost || !strings.HasSuffix(entry.Request.URL, "/function/figlet?name=openfaas") {
+		t.Fatalf("unexpected request: %s %s", entry.Request.Method, entry.Request.URL)
+	}
+	if len(entry.Request.QueryString) != 1 || entry.Request.QueryString[0].Value != "openfaas" {
+		t.Fatalf("unexpected query string: %v", entry.Request.QueryString)
+	}
+	if entry.Request.PostData == nil || entry.Request.PostData.Text != "hello" {
+		t.Fatalf("want the request body, got: %v", entry.Request.PostData)
+	}
+	if got := headerValue(entry.Request.Headers, "Authorization"); got != "Bearer "+Redacted {
+		t.Fatalf("want the Authorization header redacted, got: %q", got)
+	}
+	if got := headerValue(entry.Response.Headers, "Set-Cookie"); got != Redacted {
+		t.Fatalf("want the Set-Cookie header redacted, got: %q", got)
+	}
+
+	if entry.Response.Status != http.StatusAccepted || entry.Response.StatusText != "Accepted" {
+		t.Fatalf("unexpected status: %d %s", entry.Response.Status, entry.Response.StatusText)
+	}
+	if entry.Response.Content.Text != `{"status":"ok"}` || entry.Response.Content.Size != 15 {
+		t.Fatalf("unexpected content: %v", entry.Response.Content)
+	}
+	if entry.Time < 0 || entry.Timings.Wait < 0 || entry.Timings.Receive < 0 {
+		t.Fatalf("unexpected timings: %v", entry.Timings)
+	}
+}
+
+func Test_Recorder_RedactsSecretValues(t *testing.T) {
+	var received string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer s.Close()
+
+	recorder, path := newTestRecorder(t)
+	client := &http.Client{Transport: recorder.Wrap(nil)}
+
+	for _, method := range []string{http.MethodPost, http.MethodPut} {
+		req, _ := http.NewRequest(method, s.URL+"/system/secrets", strings.NewReader(`{"name":"db-password","value":"hunter2"}`))
+		res, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+
+		if received != `{"name":"db-password","value":"hunter2"}` {
+			t.Fatalf("the secret sent to the gateway was changed: %q", received)
+		}
+	}
+
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	archive := readArchive(t, path)
+	if len(archive.Log.Entries) != 2 {
+		t.Fatalf("want 2 entries, got: %d", len(archive.Log.Entries))
+	}
+
+	for _, entry := range archive.Log.Entries {
+		text := entry.Request.PostData.Text
+		if strings.Contains(text, "hunter2") {
+			t.Fatalf("want the secret value redacted, got: %s", text)
+		}
+		if !strings.Contains(text, `"name":"db-password"`) {
+			t.Fatalf("want the secret name kept, got: %s", text)
+		}
+	}
+}
+
+func Test_Recorder_RecordsErrors(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := s.URL
+	s.Close()
+
+	recorder, path := newTestRecorder(t)
+	client := &http.Client{Transport: recorder.Wrap(nil)}
+
+	if _, err := client.Get(url + "/system/functions"); err == nil {
+		t.Fatalf("want an error")
+	}
+
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	archive := readArchive(t, path)
+	if len(archive.Log.Entries) != 1 {
+		t.Fatalf("want 1 entry, got: %d", len(archive.Log.Entries))
+	}
+	if entry := archive.Log.Entries[0]; entry.Response.Status != 0 || len(entry.Comment) == 0 {
+		t.Fatalf("want the error recorded, got: %d %q", entry.Response.Status, entry.Comment)
+	}
+}
+
+func Test_Recorder_ConcurrentRequests(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer s.Close()
+
+	recorder, path := newTestRecorder(t)
+	client := &http.Client{Transport: recorder.Wrap(nil)}
+
+	const requests = 50
+	wg := sync.WaitGroup{}
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			res, err := client.Get(s.URL + "/system/functions")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	archive := readArchive(t, path)
+	if len(archive.Log.Entries) != requests {
+		t.Fatalf("want %d entries, got: %d", requests, len(archive.Log.Entries))
+	}
+
+	files, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("want only the archive to be left, got: %d files", len(files))
+	}
+}
+
+func Test_RedactAuthorization(t *testing.T) {
+	cases := map[string]string{
+		"Bearer eyJhbGciOi":  "Bearer " + Redacted,
+		"bearer eyJhbGciOi":  "bearer " + Redacted,
+		"Basic YWRtaW46cGFz": "Basic " + Redacted,
+		"Token abc":          Redacted,
+		"secret":             Redacted,
+	}
+
+	for value, want := range cases {
+		if got := RedactAuthorization(value); got != want {
+			t.Errorf("%q: want %q, got %q", value, want, got)
+		}
+	}
+}
+
+func Test_bodyText_EncodesBinary(t *testing.T) {
+	text, encoded := bodyText([]byte{0xff, 0xfe, 0x00}, "application/octet-stream")
+	if !encoded || text != "//4A" {
+		t.Fatalf("want base64, got: %q, %v", text, encoded)
+	}
+
+	text, encoded = bodyText([]byte("hello"), "text/plain; charset=utf-8")
+	if encoded || text != "hello" {
+		t.Fatalf("want plain text, got: %q, %v", text, encoded)
+	}
+}
+
+func headerValue(headers []NameValue, name string) string {
+	for _, header := range headers {
+		if header.Name == name {
+			return header.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,173 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+// Package history records each deployment of a function in the faas-cli config
+// directory, so that an earlier version can be looked up and re-deployed.
+package history
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/openfaas/faas-cli/config"
+	"github.com/openfaas/faas-cli/proxy"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultDir is the folder within the config directory used for history
+	DefaultDir = "history"
+
+	// MaxRevisions is the number of revisions kept for each function
+	MaxRevisions = 20
+
+	// defaultNamespace is the folder used when a function was deployed
+	// without a namespace. It can't clash with a valid namespace name.
+	defaultNamespace = "_"
+)
+
+var unsafePathChars = regexp.MustCompile(`[^a-zA-Z0-9.\-]+`)
+
+// Revision is a record of a single deployment of a function
+type Revision struct {
+	Revision  int                      `yaml:"revision"`
+	Timestamp time.Time                `yaml:"timestamp"`
+	GitSHA    string                   `yaml:"gitSha,omitempty"`
+	Gateway   string                   `yaml:"gateway"`
+	Namespace string                   `yaml:"namespace,omitempty"`
+	Spec      proxy.DeployFunctionSpec `yaml:"spec"`
+}
+
+// Record saves a new revision for the function described by spec. Credentials
+// held in the spec are removed before it is written to disk.
+func Record(gateway string, spec proxy.DeployFunctionSpec, gitSHA string) (Revision, error) {
+	revisions, err := List(gateway, spec.Namespace, spec.FunctionName)
+	if err != nil {
+		return Revision{}, err
+	}
+
+	spec.Token = ""
+	spec.RegistryAuth = ""
+
+	next := 1
+	if len(revisions) > 0 {
+		next = revisions[len(revisions)-1].Revision + 1
+	}
+
+	revision := Revision{
+		Revision:  next,
+		Timestamp: time.Now().UTC(),
+		GitSHA:    gitSHA,
+		Gateway:   gateway,
+		Namespace: spec.Namespace,
+		Spec:      spec,
+	}
+
+	revisions = append(revisions, revision)
+	if len(revisions) > MaxRevisions {
+		revisions = revisions[len(revisions)-MaxRevisions:]
+	}
+
+	filePath, err := historyFile(gateway, spec.Namespace, spec.FunctionName)
+	if err != nil {
+		return Revision{}, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), config.DefaultPermissions); err != nil {
+		return Revision{}, err
+	}
+
+	var buff bytes.Buffer
+	yamlEncoder := yaml.NewEncoder(&buff)
+	yamlEncoder.SetIndent(2)
+	if err := yamlEncoder.Encode(revisions); err != nil {
+		return Revision{}, err
+	}
+
+	if err := os.WriteFile(filePath, buff.Bytes(), 0600); err != nil {
+		return Revision{}, err
+	}
+
+	return revision, nil
+}
+
+// List returns the recorded revisions of a function, oldest first
+func List(gateway, namespace, name string) ([]Revision, error) {
+	filePath, err := historyFile(gateway, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Revision{}, nil
+		}
+		return nil, err
+	}
+
+	revisions := []Revision{}
+	if err := yaml.Unmarshal(data, &revisions); err != nil {
+		return nil, fmt.Errorf("unable to parse history for %s: %w", name, err)
+	}
+
+	return revisions, nil
+}
+
+// Get returns a single revision of a function. When revision is 0, the
+// revision before the latest one is returned.
+func Get(gateway, namespace, name string, revision int) (Revision, error) {
+	revisions, err := List(gateway, namespace, name)
+	if err != nil {
+		return Revision{}, err
+	}
+
+	if revision == 0 {
+		if len(revisions) < 2 {
+			return Revision{}, fmt.Errorf("no previous revision found for %s", name)
+		}
+		return revisions[len(revisions)-2], nil
+	}
+
+	for _, r := range revisions {
+		if r.Revision == revision {
+			return r, nil
+		}
+	}
+
+	return Revision{}, fmt.Errorf("revision %d not found for %s", revision, name)
+}
+
+// historyFile returns the path of the file holding the revisions of a function,
+// grouped by gateway and namespace.
+func historyFile(gateway, namespace, name string) (string, error) {
+	dirPath, err := homedir.Expand(config.ConfigDir())
+	if err != nil {
+		return "", err
+	}
+
+	if len(namespace) == 0 {
+		namespace = defaultNamespace
+	}
+
+	return filepath.Join(dirPath, DefaultDir, gatewayDir(gateway), safePath(namespace), safePath(name)+".yml"), nil
+}
+
+// gatewayDir turns a gateway URL into a folder name
+func gatewayDir(gateway string) string {
+	u, err := url.Parse(gateway)
+	if err != nil || len(u.Host) == 0 {
+		return safePath(gateway)
+	}
+	return safePath(u.Host + u.Path)
+}
+
+func safePath(s string) string {
+	return unsafePathChars.ReplaceAllString(s, "_")
+}
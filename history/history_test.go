@@ -0,0 +1,110 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package history
+
+import (
+	"testing"
+
+	"github.com/openfaas/faas-cli/config"
+	"github.com/openfaas/faas-cli/proxy"
+)
+
+func Test_Record_StripsCredentials(t *testing.T) {
+	t.Setenv(config.ConfigLocationEnv, t.TempDir())
+
+	gateway := "http://127.0.0.1:8080"
+	spec := proxy.DeployFunctionSpec{
+		FunctionName: "figlet",
+		Image:        "figlet:0.1.0",
+		Token:        "secret-token",
+		RegistryAuth: "secret-auth",
+		EnvVars:      map[string]string{"mode": "fast"},
+	}
+
+	if _, err := Record(gateway, spec, "abc123"); err != nil {
+		t.Fatal(err)
+	}
+
+	revisions, err := List(gateway, "", "figlet")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(revisions) != 1 {
+		t.Fatalf("want 1 revision, got %d", len(revisions))
+	}
+
+	got := revisions[0]
+	if got.Revision != 1 || got.GitSHA != "abc123" || got.Gateway != gateway {
+		t.Errorf("unexpected revision: %+v", got)
+	}
+
+	if got.Spec.Token != "" || got.Spec.RegistryAuth != "" {
+		t.Errorf("want credentials to be removed, got token: %q, registry auth: %q", got.Spec.Token, got.Spec.RegistryAuth)
+	}
+
+	if got.Spec.EnvVars["mode"] != "fast" {
+		t.Errorf("want env var mode=fast, got %v", got.Spec.EnvVars)
+	}
+}
+
+func Test_Get_PreviousAndNumbered(t *testing.T) {
+	t.Setenv(config.ConfigLocationEnv, t.TempDir())
+
+	gateway := "https://gw.example.com"
+	for _, image := range []string{"figlet:0.1.0", "figlet:0.2.0", "figlet:0.3.0"} {
+		spec := proxy.DeployFunctionSpec{FunctionName: "figlet", Image: image, Namespace: "staging"}
+		if _, err := Record(gateway, spec, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	previous, err := Get(gateway, "staging", "figlet", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if previous.Revision != 2 || previous.Spec.Image != "figlet:0.2.0" {
+		t.Errorf("want revision 2 with figlet:0.2.0, got %d with %s", previous.Revision, previous.Spec.Image)
+	}
+
+	first, err := Get(gateway, "staging", "figlet", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Spec.Image != "figlet:0.1.0" {
+		t.Errorf("want figlet:0.1.0, got %s", first.Spec.Image)
+	}
+
+	if _, err := Get(gateway, "staging", "figlet", 10); err == nil {
+		t.Errorf("want an error for a missing revision")
+	}
+
+	if _, err := Get(gateway, "", "figlet", 0); err == nil {
+		t.Errorf("want an error when there is no history in the namespace")
+	}
+}
+
+func Test_Record_KeepsMaxRevisions(t *testing.T) {
+	t.Setenv(config.ConfigLocationEnv, t.TempDir())
+
+	gateway := "http://127.0.0.1:8080"
+	for i := 0; i < MaxRevisions+5; i++ {
+		if _, err := Record(gateway, proxy.DeployFunctionSpec{FunctionName: "figlet"}, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	revisions, err := List(gateway, "", "figlet")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(revisions) != MaxRevisions {
+		t.Fatalf("want %d revisions, got %d", MaxRevisions, len(revisions))
+	}
+
+	if last := revisions[len(revisions)-1].Revision; last != MaxRevisions+5 {
+		t.Errorf("want last revision to be %d, got %d", MaxRevisions+5, last)
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/openfaas/faas-cli/config"
+)
+
+// Names of the options saved in config.AuthConfig for an OIDC login
+const (
+	OptionIssuer       = "oidc_issuer"
+	OptionClientID     = "client_id"
+	OptionTokenURL     = "token_url"
+	OptionRefreshToken = config.RefreshTokenOption
+	OptionExpiry       = "expiry"
+)
+
+// NewAuthConfig creates the auth entry saved for an OIDC login
+func NewAuthConfig(gateway, issuer, clientID string, provider *Provider, token *Token) config.AuthConfig {
+	authConfig := config.AuthConfig{
+		Gateway: gateway,
+		Auth:    config.Oauth2AuthType,
+	}
+	setToken(&authConfig, token)
+
+	setOption(&authConfig, OptionIssuer, issuer)
+	setOption(&authConfig, OptionClientID, clientID)
+	setOption(&authConfig, OptionTokenURL, provider.TokenEndpoint)
+
+	return authConfig
+}
+
+// IsRefreshable reports whether an auth entry holds a refresh token which can
+// be used to renew its access token.
+func IsRefreshable(authConfig config.AuthConfig) bool {
+	return authConfig.Auth == config.Oauth2AuthType &&
+		len(GetOption(authConfig, OptionRefreshToken)) > 0 &&
+		len(GetOption(authConfig, OptionTokenURL)) > 0
+}
+
+// TokenFromAuthConfig reads the token saved in an auth entry
+func TokenFromAuthConfig(authConfig config.AuthConfig) *Token {
+	token := &Token{
+		AccessToken:  authConfig.Token,
+		RefreshToken: GetOption(authConfig, OptionRefreshToken),
+	}
+
+	if expiry := GetOption(authConfig, OptionExpiry); len(expiry) > 0 {
+		if t, err := time.Parse(time.RFC3339, expiry); err == nil {
+			token.Expiry = t
+		}
+	}
+
+	return token
+}
+
+// GetOption returns the value of a named option in an auth entry
+func GetOption(authConfig config.AuthConfig, name string) string {
+	for _, o := range authConfig.Options {
+		if o.Name == name {
+			return o.Value
+		}
+	}
+	return ""
+}
+
+func setOption(authConfig *config.AuthConfig, name, value string) {
+	for i, o := range authConfig.Options {
+		if o.Name == name {
+			if len(value) == 0 {
+				authConfig.Options = append(authConfig.Options[:i], authConfig.Options[i+1:]...)
+			} else {
+				authConfig.Options[i].Value = value
+			}
+			return
+		}
+	}
+
+	if len(value) > 0 {
+		authConfig.Options = append(authConfig.Options, config.Option{Name: name, Value: value})
+	}
+}
+
+func setToken(authConfig *config.AuthConfig, token *Token) {
+	authConfig.Token = token.AccessToken
+	setOption(authConfig, OptionRefreshToken, token.RefreshToken)
+
+	expiry := ""
+	if !token.Expiry.IsZero() {
+		expiry = token.Expiry.UTC().Format(time.RFC3339)
+	}
+	setOption(authConfig, OptionExpiry, expiry)
+}
+
+// TokenAuth sets the access token from a saved OIDC login on each request.
+// When the token has expired it is refreshed, and the new token is saved to
+// the config file.
+type TokenAuth struct {
+	client *http.Client
+
+	lock       sync.Mutex
+	authConfig config.AuthConfig
+}
+
+// NewTokenAuth creates a TokenAuth for a saved auth entry. Tokens are
+// refreshed through transport, or the default transport when it is nil.
+func NewTokenAuth(authConfig config.AuthConfig, transport http.RoundTripper) *TokenAuth {
+	return &TokenAuth{
+		client:     &http.Client{Timeout: 30 * time.Second, Transport: transport},
+		authConfig: authConfig,
+	}
+}
+
+// Set adds the Authorization header to a request
+func (a *TokenAuth) Set(req *http.Request) error {
+	token, err := a.Token()
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+// Token returns a valid access token, refreshing it when it has expired
+func (a *TokenAuth) Token() (string, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	current := TokenFromAuthConfig(a.authConfig)
+	if !current.Expired() {
+		return current.AccessToken, nil
+	}
+
+	token, err := Refresh(context.Background(), a.client,
+		GetOption(a.authConfig, OptionTokenURL),
+		GetOption(a.authConfig, OptionClientID),
+		current.RefreshToken)
+	if err != nil {
+		return "", fmt.Errorf("unable to refresh the token for %s, run \"faas-cli login\" again: %w", a.authConfig.Gateway, err)
+	}
+
+	setToken(&a.authConfig, token)
+	if err := config.UpdateAuthConfig(a.authConfig); err != nil {
+		return "", fmt.Errorf("unable to save the refreshed token: %w", err)
+	}
+
+	return token.AccessToken, nil
+}
@@ -0,0 +1,126 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// pollUnit is the unit of the polling interval returned by the provider
+var pollUnit = time.Second
+
+type deviceAuthResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// DeviceLogin runs the device authorization grant (RFC 8628). The user is
+// asked to visit a URL on any device and enter a code, while the token
+// endpoint is polled until they have approved the request.
+func DeviceLogin(ctx context.Context, client *http.Client, provider *Provider, clientID string, scopes []string, out io.Writer) (*Token, error) {
+	if len(provider.DeviceAuthorizationEndpoint) == 0 {
+		return nil, fmt.Errorf("the OIDC issuer does not support the device authorization grant")
+	}
+
+	form := url.Values{}
+	form.Set("client_id", clientID)
+	form.Set("scope", strings.Join(scopes, " "))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, provider.DeviceAuthorizationEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to the device authorization endpoint: %w", err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		oauthErr := &Error{}
+		if err := json.Unmarshal(body, oauthErr); err == nil && len(oauthErr.Code) > 0 {
+			return nil, oauthErr
+		}
+		return nil, fmt.Errorf("unexpected status code: %d from device authorization endpoint: %s", res.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	auth := deviceAuthResponse{}
+	if err := json.Unmarshal(body, &auth); err != nil {
+		return nil, fmt.Errorf("unable to parse device authorization response: %w", err)
+	}
+
+	if len(auth.VerificationURIComplete) > 0 {
+		fmt.Fprintf(out, "Open %s to log in, and check that the code is: %s\n", auth.VerificationURIComplete, auth.UserCode)
+	} else {
+		fmt.Fprintf(out, "Open %s to log in, and enter the code: %s\n", auth.VerificationURI, auth.UserCode)
+	}
+
+	interval := auth.Interval
+	if interval <= 0 {
+		interval = 5
+	}
+
+	if auth.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(auth.ExpiresIn)*pollUnit)
+		defer cancel()
+	}
+
+	tokenForm := url.Values{}
+	tokenForm.Set("grant_type", deviceCodeGrantType)
+	tokenForm.Set("device_code", auth.DeviceCode)
+	tokenForm.Set("client_id", clientID)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for the login to be approved")
+		case <-time.After(time.Duration(interval) * pollUnit):
+		}
+
+		token, err := requestToken(ctx, client, provider.TokenEndpoint, tokenForm)
+		if err == nil {
+			return token, nil
+		}
+
+		var oauthErr *Error
+		if !errors.As(err, &oauthErr) {
+			return nil, err
+		}
+
+		switch oauthErr.Code {
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5
+		case "access_denied":
+			return nil, fmt.Errorf("the login was denied")
+		case "expired_token":
+			return nil, fmt.Errorf("the login code expired before it was approved")
+		default:
+			return nil, err
+		}
+	}
+}
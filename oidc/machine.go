@@ -0,0 +1,196 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package oidc
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/openfaas/faas-cli/config"
+	"github.com/openfaas/go-sdk"
+)
+
+// Options saved for a machine login, which record how to mint a new token
+// rather than the token itself.
+const (
+	OptionGrant            = "grant"
+	OptionClientSecretFile = "client_secret_file"
+	OptionIDTokenFile      = "id_token_file"
+	OptionScope            = "scope"
+	OptionAudience         = "audience"
+)
+
+// Grants used for machine logins
+const (
+	GrantClientCredentials = "client_credentials"
+	GrantTokenExchange     = "token_exchange"
+)
+
+// NewClientCredentialsConfig creates the auth entry for a login with the
+// client credentials grant. Only the path to the client secret is saved.
+func NewClientCredentialsConfig(gateway, clientID, clientSecretFile, tokenURL, scope, audience string) config.AuthConfig {
+	authConfig := config.AuthConfig{
+		Gateway: gateway,
+		Auth:    config.Oauth2AuthType,
+	}
+
+	setOption(&authConfig, OptionGrant, GrantClientCredentials)
+	setOption(&authConfig, OptionClientID, clientID)
+	setOption(&authConfig, OptionClientSecretFile, clientSecretFile)
+	setOption(&authConfig, OptionTokenURL, tokenURL)
+	setOption(&authConfig, OptionScope, scope)
+	setOption(&authConfig, OptionAudience, audience)
+
+	return authConfig
+}
+
+// NewTokenExchangeConfig creates the auth entry for a login which exchanges
+// an ID token read from a file, i.e. one issued to a CI job, for an OpenFaaS
+// token.
+func NewTokenExchangeConfig(gateway, idTokenFile, tokenURL, scope, audience string) config.AuthConfig {
+	authConfig := config.AuthConfig{
+		Gateway: gateway,
+		Auth:    config.Oauth2AuthType,
+	}
+
+	setOption(&authConfig, OptionGrant, GrantTokenExchange)
+	setOption(&authConfig, OptionIDTokenFile, idTokenFile)
+	setOption(&authConfig, OptionTokenURL, tokenURL)
+	setOption(&authConfig, OptionScope, scope)
+	setOption(&authConfig, OptionAudience, audience)
+
+	return authConfig
+}
+
+// IsMachineLogin reports whether an auth entry mints its tokens with the
+// client credentials grant or a token exchange.
+func IsMachineLogin(authConfig config.AuthConfig) bool {
+	return authConfig.Auth == config.Oauth2AuthType && len(GetOption(authConfig, OptionGrant)) > 0
+}
+
+// NewTokenSource creates a TokenSource which mints tokens for a machine
+// login. Tokens are cached by the TokenSource until they expire.
+func NewTokenSource(authConfig config.AuthConfig) (sdk.TokenSource, error) {
+	tokenURL := GetOption(authConfig, OptionTokenURL)
+	if len(tokenURL) == 0 {
+		return nil, fmt.Errorf("no token URL saved for %s, run \"faas-cli login\" again", authConfig.Gateway)
+	}
+
+	switch grant := GetOption(authConfig, OptionGrant); grant {
+	case GrantClientCredentials:
+		secret, err := readTokenFile(GetOption(authConfig, OptionClientSecretFile))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the client secret: %w", err)
+		}
+
+		return sdk.NewClientCredentialsTokenSource(
+			GetOption(authConfig, OptionClientID),
+			secret,
+			tokenURL,
+			GetOption(authConfig, OptionScope),
+			GrantClientCredentials,
+			GetOption(authConfig, OptionAudience),
+		), nil
+
+	case GrantTokenExchange:
+		return &exchangeTokenSource{
+			tokenURL:    tokenURL,
+			idTokenFile: GetOption(authConfig, OptionIDTokenFile),
+			scope:       strings.Fields(GetOption(authConfig, OptionScope)),
+			audience:    strings.Fields(GetOption(authConfig, OptionAudience)),
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown grant %q saved for %s", grant, authConfig.Gateway)
+	}
+}
+
+// exchangeTokenSource exchanges an ID token read from a file for an OpenFaaS
+// token. The file is read again each time a new token is needed, so that a
+// rotated ID token is picked up.
+type exchangeTokenSource struct {
+	tokenURL    string
+	idTokenFile string
+	scope       []string
+	audience    []string
+
+	lock  sync.Mutex
+	token *sdk.Token
+}
+
+func (ts *exchangeTokenSource) Token() (string, error) {
+	ts.lock.Lock()
+	defer ts.lock.Unlock()
+
+	if ts.token != nil && !ts.token.Expired() {
+		return ts.token.IDToken, nil
+	}
+
+	idToken, err := readTokenFile(ts.idTokenFile)
+	if err != nil {
+		return "", fmt.Errorf("unable to read the ID token: %w", err)
+	}
+
+	options := []sdk.ExchangeOption{}
+	if len(ts.scope) > 0 {
+		options = append(options, sdk.WithScope(ts.scope))
+	}
+	if len(ts.audience) > 0 {
+		options = append(options, sdk.WithAudience(ts.audience))
+	}
+
+	token, err := sdk.ExchangeIDToken(ts.tokenURL, idToken, options...)
+	if err != nil {
+		return "", fmt.Errorf("unable to exchange the ID token for an OpenFaaS token: %w", err)
+	}
+
+	ts.token = token
+	return token.IDToken, nil
+}
+
+// SourceAuth sets the bearer token from a TokenSource on each request
+type SourceAuth struct {
+	sdk.TokenSource
+}
+
+// NewMachineAuth creates a SourceAuth for a machine login
+func NewMachineAuth(authConfig config.AuthConfig) (*SourceAuth, error) {
+	ts, err := NewTokenSource(authConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &SourceAuth{TokenSource: ts}, nil
+}
+
+// Set adds the Authorization header to a request
+func (a *SourceAuth) Set(req *http.Request) error {
+	token, err := a.Token()
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
+}
+
+func readTokenFile(path string) (string, error) {
+	if len(path) == 0 {
+		return "", fmt.Errorf("no file given")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	value := strings.TrimSpace(string(data))
+	if len(value) == 0 {
+		return "", fmt.Errorf("%s is empty", path)
+	}
+
+	return value, nil
+}
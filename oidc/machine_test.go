@@ -0,0 +1,136 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package oidc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openfaas/faas-cli/config"
+)
+
+func newFakeTokenEndpoint(t *testing.T, check func(*testing.T, *http.Request)) (*httptest.Server, *int) {
+	calls := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		r.ParseForm()
+		check(t, r)
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "openfaas-token",
+			"expires_in":   3600,
+		})
+	}))
+	t.Cleanup(s.Close)
+
+	return s, &calls
+}
+
+func writeTokenFile(t *testing.T, name, value string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(value+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func Test_NewTokenSource_ClientCredentials(t *testing.T) {
+	s, calls := newFakeTokenEndpoint(t, func(t *testing.T, r *http.Request) {
+		if got := r.Form.Get("grant_type"); got != GrantClientCredentials {
+			t.Errorf("want client_credentials grant, got: %q", got)
+		}
+		if r.Form.Get("client_id") != "ci" || r.Form.Get("client_secret") != "s3cr3t" {
+			t.Errorf("unexpected client credentials: %v", r.Form)
+		}
+		if got := r.Form.Get("audience"); got != "openfaas" {
+			t.Errorf("want audience openfaas, got: %q", got)
+		}
+	})
+
+	secretFile := writeTokenFile(t, "client-secret", "s3cr3t")
+	authConfig := NewClientCredentialsConfig("http://127.0.0.1:8080", "ci", secretFile, s.URL, "openid", "openfaas")
+
+	if !IsMachineLogin(authConfig) {
+		t.Fatalf("want a machine login")
+	}
+	if len(authConfig.Token) > 0 {
+		t.Errorf("want no static token to be saved, got: %q", authConfig.Token)
+	}
+
+	auth, err := NewMachineAuth(authConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/system/functions", nil)
+		if err := auth.Set(req); err != nil {
+			t.Fatal(err)
+		}
+		if got := req.Header.Get("Authorization"); got != "Bearer openfaas-token" {
+			t.Errorf("want bearer token, got: %q", got)
+		}
+	}
+
+	if *calls != 1 {
+		t.Errorf("want the token to be cached, got %d calls to the token endpoint", *calls)
+	}
+}
+
+func Test_NewTokenSource_TokenExchange(t *testing.T) {
+	s, calls := newFakeTokenEndpoint(t, func(t *testing.T, r *http.Request) {
+		if got := r.Form.Get("grant_type"); got != "urn:ietf:params:oauth:grant-type:token-exchange" {
+			t.Errorf("want token exchange grant, got: %q", got)
+		}
+		if got := r.Form.Get("subject_token"); got != "ci-id-token" {
+			t.Errorf("want ID token from file, got: %q", got)
+		}
+	})
+
+	idTokenFile := writeTokenFile(t, "id-token", "ci-id-token")
+	authConfig := NewTokenExchangeConfig("http://127.0.0.1:8080", idTokenFile, s.URL, "", "")
+
+	ts, err := NewTokenSource(authConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		token, err := ts.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token != "openfaas-token" {
+			t.Errorf("want exchanged token, got: %q", token)
+		}
+	}
+
+	if *calls != 1 {
+		t.Errorf("want the token to be cached, got %d calls to the token endpoint", *calls)
+	}
+}
+
+func Test_NewTokenSource_MissingSecretFile(t *testing.T) {
+	authConfig := NewClientCredentialsConfig("http://127.0.0.1:8080", "ci", filepath.Join(t.TempDir(), "missing"), "http://127.0.0.1:1/token", "", "")
+
+	if _, err := NewTokenSource(authConfig); err == nil {
+		t.Errorf("want error for a missing client secret file")
+	}
+}
+
+func Test_IsMachineLogin(t *testing.T) {
+	if IsMachineLogin(config.AuthConfig{Auth: config.Oauth2AuthType, Token: "abc"}) {
+		t.Errorf("want a static token not to be a machine login")
+	}
+
+	login := NewAuthConfig("http://127.0.0.1:8080", "https://issuer", "faas-cli",
+		&Provider{TokenEndpoint: "https://issuer/token"}, &Token{AccessToken: "abc", RefreshToken: "def"})
+	if IsMachineLogin(login) {
+		t.Errorf("want an interactive OIDC login not to be a machine login")
+	}
+}
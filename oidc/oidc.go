@@ -0,0 +1,175 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+// Package oidc implements the OpenID Connect flows used by "faas-cli login"
+// to obtain, store and refresh tokens from an identity provider.
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// expiryDelta treats a token as expired slightly before its expiry time, to
+// allow for clock skew and the time taken by the request.
+const expiryDelta = 10 * time.Second
+
+// Provider holds the endpoints published by an OIDC issuer
+type Provider struct {
+	Issuer                      string `json:"issuer"`
+	AuthorizationEndpoint       string `json:"authorization_endpoint"`
+	TokenEndpoint               string `json:"token_endpoint"`
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint,omitempty"`
+}
+
+// Token is the result of a successful login or refresh
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	IDToken      string
+
+	// Expiry of the access token, a zero value means it does not expire
+	Expiry time.Time
+}
+
+// Expired reports whether the access token has expired
+func (t *Token) Expired() bool {
+	if t.Expiry.IsZero() {
+		return false
+	}
+	return t.Expiry.Add(-expiryDelta).Before(time.Now())
+}
+
+// Error is an error response from an OAuth 2.0 endpoint
+type Error struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+func (e *Error) Error() string {
+	if len(e.Description) > 0 {
+		return fmt.Sprintf("%s: %s", e.Code, e.Description)
+	}
+	return e.Code
+}
+
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token"`
+	IDToken      string `json:"id_token"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+// Discover reads the provider's endpoints from its
+// .well-known/openid-configuration document.
+func Discover(ctx context.Context, client *http.Client, issuer string) (*Provider, error) {
+	discoveryURL := strings.TrimRight(issuer, "/") + "/.well-known/openid-configuration"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid issuer URL: %s", issuer)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to the OIDC issuer %s: %w", issuer, err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d reading %s", res.StatusCode, discoveryURL)
+	}
+
+	provider := &Provider{}
+	if err := json.Unmarshal(body, provider); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", discoveryURL, err)
+	}
+
+	if len(provider.TokenEndpoint) == 0 {
+		return nil, fmt.Errorf("no token_endpoint found in %s", discoveryURL)
+	}
+
+	return provider, nil
+}
+
+// Refresh exchanges a refresh token for a new access token. The refresh token
+// is kept when the provider does not return a new one.
+func Refresh(ctx context.Context, client *http.Client, tokenURL, clientID, refreshToken string) (*Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("client_id", clientID)
+	form.Set("refresh_token", refreshToken)
+
+	token, err := requestToken(ctx, client, tokenURL, form)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(token.RefreshToken) == 0 {
+		token.RefreshToken = refreshToken
+	}
+
+	return token, nil
+}
+
+// requestToken posts a form to the token endpoint, OAuth errors are returned
+// as *Error.
+func requestToken(ctx context.Context, client *http.Client, tokenURL string, form url.Values) (*Token, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to the token endpoint %s: %w", tokenURL, err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		oauthErr := &Error{}
+		if err := json.Unmarshal(body, oauthErr); err == nil && len(oauthErr.Code) > 0 {
+			return nil, oauthErr
+		}
+		return nil, fmt.Errorf("unexpected status code: %d from token endpoint: %s", res.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	tr := tokenResponse{}
+	if err := json.Unmarshal(body, &tr); err != nil {
+		return nil, fmt.Errorf("unable to parse token response: %w", err)
+	}
+
+	if len(tr.AccessToken) == 0 {
+		return nil, fmt.Errorf("no access_token in token response")
+	}
+
+	token := &Token{
+		AccessToken:  tr.AccessToken,
+		RefreshToken: tr.RefreshToken,
+		IDToken:      tr.IDToken,
+	}
+	if tr.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(tr.ExpiresIn) * time.Second)
+	}
+
+	return token, nil
+}
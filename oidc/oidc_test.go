@@ -0,0 +1,304 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package oidc
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openfaas/faas-cli/config"
+)
+
+// fakeIssuer is a stand-in for an OIDC identity provider
+type fakeIssuer struct {
+	*httptest.Server
+
+	lock         sync.Mutex
+	pending      int
+	challenge    string
+	redirectURI  string
+	tokenGrants  []string
+	refreshToken string
+}
+
+func newFakeIssuer(t *testing.T) *fakeIssuer {
+	issuer := &fakeIssuer{refreshToken: "refresh-1"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(Provider{
+			Issuer:                      issuer.URL,
+			AuthorizationEndpoint:       issuer.URL + "/authorize",
+			TokenEndpoint:               issuer.URL + "/token",
+			DeviceAuthorizationEndpoint: issuer.URL + "/device",
+		})
+	})
+
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"device_code":      "device-1",
+			"user_code":        "ABCD-EFGH",
+			"verification_uri": issuer.URL + "/activate",
+			"expires_in":       600,
+			"interval":         1,
+		})
+	})
+
+	mux.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("code_challenge_method") != "S256" {
+			t.Errorf("want S256 code challenge, got: %q", q.Get("code_challenge_method"))
+		}
+
+		issuer.lock.Lock()
+		issuer.challenge = q.Get("code_challenge")
+		issuer.redirectURI = q.Get("redirect_uri")
+		issuer.lock.Unlock()
+
+		http.Redirect(w, r, q.Get("redirect_uri")+"?code=code-1&state="+url.QueryEscape(q.Get("state")), http.StatusFound)
+	})
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+
+		issuer.lock.Lock()
+		defer issuer.lock.Unlock()
+		issuer.tokenGrants = append(issuer.tokenGrants, r.Form.Get("grant_type"))
+
+		switch r.Form.Get("grant_type") {
+		case deviceCodeGrantType:
+			if issuer.pending > 0 {
+				issuer.pending--
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(Error{Code: "authorization_pending"})
+				return
+			}
+		case "authorization_code":
+			if codeChallenge(r.Form.Get("code_verifier")) != issuer.challenge {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(Error{Code: "invalid_grant", Description: "PKCE verification failed"})
+				return
+			}
+			if r.Form.Get("redirect_uri") != issuer.redirectURI {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(Error{Code: "invalid_grant", Description: "redirect_uri mismatch"})
+				return
+			}
+		case "refresh_token":
+			if r.Form.Get("refresh_token") != issuer.refreshToken {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(Error{Code: "invalid_grant"})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token": "access-refreshed",
+				"expires_in":   3600,
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  "access-1",
+			"refresh_token": issuer.refreshToken,
+			"id_token":      "id-1",
+			"expires_in":    3600,
+		})
+	})
+
+	issuer.Server = httptest.NewServer(mux)
+	t.Cleanup(issuer.Close)
+
+	return issuer
+}
+
+func Test_Discover(t *testing.T) {
+	issuer := newFakeIssuer(t)
+
+	provider, err := Discover(context.Background(), http.DefaultClient, issuer.URL+"/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if provider.TokenEndpoint != issuer.URL+"/token" {
+		t.Errorf("want token endpoint %s, got: %s", issuer.URL+"/token", provider.TokenEndpoint)
+	}
+}
+
+func Test_DeviceLogin(t *testing.T) {
+	defer func(unit time.Duration) { pollUnit = unit }(pollUnit)
+	pollUnit = time.Millisecond
+
+	issuer := newFakeIssuer(t)
+	issuer.pending = 2
+
+	provider, err := Discover(context.Background(), http.DefaultClient, issuer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	token, err := DeviceLogin(context.Background(), http.DefaultClient, provider, "faas-cli", []string{"openid"}, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if token.AccessToken != "access-1" || token.RefreshToken != "refresh-1" {
+		t.Errorf("unexpected token: %+v", token)
+	}
+
+	if !strings.Contains(out.String(), "ABCD-EFGH") {
+		t.Errorf("want user code in output, got: %q", out.String())
+	}
+
+	if len(issuer.tokenGrants) != 3 {
+		t.Errorf("want 3 polls of the token endpoint, got: %d", len(issuer.tokenGrants))
+	}
+}
+
+func Test_BrowserLogin(t *testing.T) {
+	issuer := newFakeIssuer(t)
+
+	provider, err := Discover(context.Background(), http.DefaultClient, issuer.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The "browser" follows the redirect from the issuer to the callback
+	openBrowser := func(address string) error {
+		go func() {
+			res, err := http.Get(address)
+			if err != nil {
+				t.Errorf("browser request failed: %s", err)
+				return
+			}
+			res.Body.Close()
+		}()
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var out bytes.Buffer
+	token, err := BrowserLogin(ctx, http.DefaultClient, provider, "faas-cli", []string{"openid"}, 0, openBrowser, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if token.AccessToken != "access-1" || token.IDToken != "id-1" {
+		t.Errorf("unexpected token: %+v", token)
+	}
+}
+
+func Test_TokenAuth_RefreshesWithTransport(t *testing.T) {
+	t.Setenv(config.ConfigLocationEnv, t.TempDir())
+
+	issuer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access-refreshed",
+			"expires_in":   3600,
+		})
+	}))
+	defer issuer.Close()
+
+	provider := &Provider{TokenEndpoint: issuer.URL + "/token"}
+	expired := &Token{
+		AccessToken:  "access-1",
+		RefreshToken: "refresh-1",
+		Expiry:       time.Now().Add(-time.Minute),
+	}
+	authConfig := NewAuthConfig("http://127.0.0.1:8080", issuer.URL, "faas-cli", provider, expired)
+
+	if _, err := NewTokenAuth(authConfig, nil).Token(); err == nil {
+		t.Fatal("want an error refreshing against a self-signed issuer with the default transport")
+	}
+
+	insecure := &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+	token, err := NewTokenAuth(authConfig, insecure).Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "access-refreshed" {
+		t.Errorf("want refreshed token, got: %q", token)
+	}
+}
+
+func Test_TokenAuth_RefreshesExpiredToken(t *testing.T) {
+	t.Setenv(config.ConfigLocationEnv, t.TempDir())
+
+	issuer := newFakeIssuer(t)
+	provider := &Provider{TokenEndpoint: issuer.URL + "/token"}
+
+	expired := &Token{
+		AccessToken:  "access-1",
+		RefreshToken: "refresh-1",
+		Expiry:       time.Now().Add(-time.Minute),
+	}
+	authConfig := NewAuthConfig("http://127.0.0.1:8080", issuer.URL, "faas-cli", provider, expired)
+	if err := config.UpdateAuthConfig(authConfig); err != nil {
+		t.Fatal(err)
+	}
+
+	auth := NewTokenAuth(authConfig, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/system/functions", nil)
+	if err := auth.Set(req); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := req.Header.Get("Authorization"); got != "Bearer access-refreshed" {
+		t.Errorf("want refreshed token, got: %q", got)
+	}
+
+	saved, err := config.LookupAuthConfig("http://127.0.0.1:8080")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if saved.Token != "access-refreshed" {
+		t.Errorf("want refreshed token to be saved, got: %q", saved.Token)
+	}
+	if GetOption(saved, OptionRefreshToken) != "refresh-1" {
+		t.Errorf("want refresh token to be kept, got: %q", GetOption(saved, OptionRefreshToken))
+	}
+	if TokenFromAuthConfig(saved).Expired() {
+		t.Errorf("want saved token to have a new expiry")
+	}
+
+	// A valid token is used without calling the issuer again
+	grants := len(issuer.tokenGrants)
+	if err := auth.Set(httptest.NewRequest(http.MethodGet, "/", nil)); err != nil {
+		t.Fatal(err)
+	}
+	if len(issuer.tokenGrants) != grants {
+		t.Errorf("want no refresh for a valid token")
+	}
+}
+
+func Test_IsRefreshable(t *testing.T) {
+	basic := config.AuthConfig{Auth: config.BasicAuthType, Token: "abc"}
+	if IsRefreshable(basic) {
+		t.Errorf("want basic auth not to be refreshable")
+	}
+
+	static := config.AuthConfig{Auth: config.Oauth2AuthType, Token: "abc"}
+	if IsRefreshable(static) {
+		t.Errorf("want oauth2 token without a refresh token not to be refreshable")
+	}
+
+	login := NewAuthConfig("http://127.0.0.1:8080", "https://issuer", "faas-cli",
+		&Provider{TokenEndpoint: "https://issuer/token"}, &Token{AccessToken: "abc", RefreshToken: "def"})
+	if !IsRefreshable(login) {
+		t.Errorf("want OIDC login to be refreshable")
+	}
+}
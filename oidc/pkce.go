@@ -0,0 +1,154 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// CallbackPath is the path of the loopback redirect URI
+const CallbackPath = "/oauth/callback"
+
+type callbackResult struct {
+	code string
+	err  error
+}
+
+// BrowserLogin runs the authorization code flow with PKCE (RFC 7636). A
+// listener is started on the loopback interface to receive the redirect from
+// the provider, and openBrowser is called with the URL the user must visit.
+// When listenPort is 0, a free port is chosen.
+func BrowserLogin(ctx context.Context, client *http.Client, provider *Provider, clientID string, scopes []string, listenPort int, openBrowser func(string) error, out io.Writer) (*Token, error) {
+	if len(provider.AuthorizationEndpoint) == 0 {
+		return nil, fmt.Errorf("no authorization_endpoint found for the OIDC issuer")
+	}
+
+	verifier, err := randomString(32)
+	if err != nil {
+		return nil, err
+	}
+	state, err := randomString(16)
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", listenPort))
+	if err != nil {
+		return nil, fmt.Errorf("unable to listen for the login callback: %w", err)
+	}
+
+	redirectURI := fmt.Sprintf("http://%s%s", listener.Addr().String(), CallbackPath)
+
+	results := make(chan callbackResult, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc(CallbackPath, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		var result callbackResult
+		switch {
+		case query.Get("state") != state:
+			result.err = fmt.Errorf("the state returned by the OIDC issuer did not match")
+		case len(query.Get("error")) > 0:
+			result.err = &Error{Code: query.Get("error"), Description: query.Get("error_description")}
+		case len(query.Get("code")) == 0:
+			result.err = fmt.Errorf("no code returned by the OIDC issuer")
+		default:
+			result.code = query.Get("code")
+		}
+
+		if result.err != nil {
+			http.Error(w, fmt.Sprintf("Login failed: %s", result.err), http.StatusBadRequest)
+		} else {
+			fmt.Fprintln(w, "Login complete, you can close this window and return to faas-cli.")
+		}
+
+		select {
+		case results <- result:
+		default:
+		}
+	})
+
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	defer server.Close()
+
+	authURL, err := url.Parse(provider.AuthorizationEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid authorization_endpoint: %w", err)
+	}
+
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", clientID)
+	query.Set("redirect_uri", redirectURI)
+	query.Set("scope", strings.Join(scopes, " "))
+	query.Set("state", state)
+	query.Set("code_challenge", codeChallenge(verifier))
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	if err := openBrowser(authURL.String()); err != nil {
+		fmt.Fprintf(out, "Open this URL in your browser to log in:\n\n%s\n\n", authURL.String())
+	} else {
+		fmt.Fprintln(out, "Your browser has been opened to log in, waiting for the login to complete...")
+	}
+
+	var result callbackResult
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("timed out waiting for the login to complete")
+	case result = <-results:
+	}
+
+	if result.err != nil {
+		return nil, result.err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", result.code)
+	form.Set("redirect_uri", redirectURI)
+	form.Set("client_id", clientID)
+	form.Set("code_verifier", verifier)
+
+	return requestToken(ctx, client, provider.TokenEndpoint, form)
+}
+
+// OpenBrowser opens a URL with the default browser of the OS
+func OpenBrowser(address string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", address)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", address)
+	default:
+		cmd = exec.Command("xdg-open", address)
+	}
+	return cmd.Start()
+}
+
+func randomString(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func codeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
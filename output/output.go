@@ -0,0 +1,163 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is a value of the --output flag
+type Format string
+
+const (
+	// TableFormat is the table printed when --output is not given
+	TableFormat Format = ""
+	// WideFormat is the table with additional columns
+	WideFormat Format = "wide"
+	// JSONFormat prints the result as indented JSON
+	JSONFormat Format = "json"
+	// YAMLFormat prints the result as YAML, with the same keys as JSON
+	YAMLFormat Format = "yaml"
+	// NameFormat prints one name per line
+	NameFormat Format = "name"
+	// GoTemplateFormat renders a Go template for each item, given as
+	// go-template=TEMPLATE
+	GoTemplateFormat Format = "go-template"
+)
+
+// FlagUsage is the help text of the --output flag
+const FlagUsage = "Output format: json, yaml, wide, name or go-template=TEMPLATE"
+
+// Result is the output of a command in a form for each format
+type Result struct {
+	// Object is printed by json and yaml, and given to a go-template once for
+	// each item when it is a slice
+	Object interface{}
+
+	// Names are printed one per line by name
+	Names []string
+
+	// Table prints the table for the default format, with more columns when
+	// wide is true
+	Table func(w io.Writer, wide bool)
+}
+
+// Printer prints a Result in the format chosen with --output
+type Printer struct {
+	Format   Format
+	template *Template
+}
+
+// NewPrinter parses the value of the --output flag
+func NewPrinter(value string) (*Printer, error) {
+	name, text, hasTemplate := strings.Cut(value, "=")
+
+	switch format := Format(strings.ToLower(name)); format {
+	case TableFormat, WideFormat, JSONFormat, YAMLFormat, NameFormat:
+		if hasTemplate {
+			return nil, fmt.Errorf("the %q output format does not take a value", name)
+		}
+		return &Printer{Format: format}, nil
+
+	case GoTemplateFormat:
+		if len(text) == 0 {
+			return nil, fmt.Errorf("give a template with --output go-template='{{.Name}}'")
+		}
+		return NewTemplatePrinter(text)
+	}
+
+	return nil, fmt.Errorf("unknown output format %q, use one of: json, yaml, wide, name or go-template=TEMPLATE", value)
+}
+
+// NewTemplatePrinter returns a Printer for a go-template, as given by the
+// --format flag
+func NewTemplatePrinter(text string) (*Printer, error) {
+	tmpl, err := NewTemplate(text, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Printer{Format: GoTemplateFormat, template: tmpl}, nil
+}
+
+// IsTable reports whether the result is printed as a table, for messages
+// which are only meant for people, such as when nothing was found.
+func (p *Printer) IsTable() bool {
+	return p.Format == TableFormat || p.Format == WideFormat
+}
+
+// Print writes result to w in the format of the Printer
+func (p *Printer) Print(w io.Writer, result Result) error {
+	switch p.Format {
+	case JSONFormat:
+		data, err := json.MarshalIndent(result.Object, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+
+	case YAMLFormat:
+		data, err := marshalYAML(result.Object)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+
+	case NameFormat:
+		for _, name := range result.Names {
+			if _, err := fmt.Fprintln(w, name); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case GoTemplateFormat:
+		v := reflect.ValueOf(result.Object)
+		if v.Kind() != reflect.Slice {
+			return p.template.Execute(w, result.Object)
+		}
+
+		for i := 0; i < v.Len(); i++ {
+			if err := p.template.Execute(w, v.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	result.Table(w, p.Format == WideFormat)
+	return nil
+}
+
+// marshalYAML encodes v as JSON first, so that the keys and the omitted
+// fields are the same as in the JSON output, then converts it to YAML in
+// block style, keeping the order of the keys.
+func marshalYAML(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	clearStyle(&doc)
+
+	return yaml.Marshal(&doc)
+}
+
+func clearStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearStyle(child)
+	}
+}
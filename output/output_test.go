@@ -0,0 +1,114 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+)
+
+type item struct {
+	Name    string            `json:"name"`
+	Count   int               `json:"count,omitempty"`
+	Enabled string            `json:"enabled"`
+	Labels  map[string]string `json:"labels,omitempty"`
+}
+
+func testResult() Result {
+	items := []item{
+		{Name: "figlet", Count: 2, Enabled: "true", Labels: map[string]string{"team": "a"}},
+		{Name: "env", Enabled: "0"},
+	}
+
+	return Result{
+		Object: items,
+		Names:  []string{"figlet", "env"},
+		Table: func(w io.Writer, wide bool) {
+			for _, i := range items {
+				if wide {
+					fmt.Fprintf(w, "%s %d\n", i.Name, i.Count)
+				} else {
+					fmt.Fprintln(w, i.Name)
+				}
+			}
+		},
+	}
+}
+
+func Test_Printer_Print(t *testing.T) {
+	cases := []struct {
+		output string
+		want   string
+	}{
+		{output: "", want: "figlet\nenv\n"},
+		{output: "wide", want: "figlet 2\nenv 0\n"},
+		{output: "name", want: "figlet\nenv\n"},
+		{output: "go-template={{.Name}}={{.Count}}", want: "figlet=2\nenv=0\n"},
+		{output: "json", want: `[
+  {
+    "name": "figlet",
+    "count": 2,
+    "enabled": "true",
+    "labels": {
+      "team": "a"
+    }
+  },
+  {
+    "name": "env",
+    "enabled": "0"
+  }
+]
+`},
+		{output: "YAML", want: `- name: figlet
+  count: 2
+  enabled: "true"
+  labels:
+    team: a
+- name: env
+  enabled: "0"
+`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.output, func(t *testing.T) {
+			p, err := NewPrinter(tc.output)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var b bytes.Buffer
+			if err := p.Print(&b, testResult()); err != nil {
+				t.Fatal(err)
+			}
+			if b.String() != tc.want {
+				t.Fatalf("want:\n%s\ngot:\n%s", tc.want, b.String())
+			}
+		})
+	}
+}
+
+func Test_Printer_TemplateForObject(t *testing.T) {
+	p, err := NewTemplatePrinter("{{.Name}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	if err := p.Print(&b, Result{Object: item{Name: "figlet"}}); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != "figlet\n" {
+		t.Fatalf("want the template rendered once, got: %q", b.String())
+	}
+}
+
+func Test_NewPrinter_Invalid(t *testing.T) {
+	for _, value := range []string{"xml", "json=1", "go-template", "go-template=", "go-template={{.Name"} {
+		if _, err := NewPrinter(value); err == nil {
+			t.Errorf("want an error for %q", value)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+// Package output renders the results of commands in formats which can be
+// read by scripts as well as by people.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/morikuni/aec"
+)
+
+var colors = map[string]aec.ANSI{
+	"black":   aec.BlackF,
+	"red":     aec.RedF,
+	"green":   aec.GreenF,
+	"yellow":  aec.YellowF,
+	"blue":    aec.BlueF,
+	"magenta": aec.MagentaF,
+	"cyan":    aec.CyanF,
+	"white":   aec.WhiteF,
+	"bold":    aec.Bold,
+	"faint":   aec.Faint,
+}
+
+// TemplateFuncs returns the functions available to every --format template:
+//
+//	color "red" .Name   colors the text, also green, yellow, blue, magenta,
+//	                    cyan, white, black, bold and faint
+//	trunc 8 .Instance   keeps the first 8 characters
+//	json .Labels        encodes the value as JSON
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"color": colorText,
+		"trunc": trunc,
+		"json":  toJSON,
+	}
+}
+
+// Template is a parsed --format template, executed once for each item
+type Template struct {
+	tmpl *template.Template
+}
+
+// NewTemplate parses text with the functions from TemplateFuncs, and any
+// extra functions given for the data of a particular command.
+func NewTemplate(text string, extra template.FuncMap) (*Template, error) {
+	tmpl := template.New("format").Funcs(TemplateFuncs())
+	if extra != nil {
+		tmpl = tmpl.Funcs(extra)
+	}
+
+	tmpl, err := tmpl.Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --format template: %w", err)
+	}
+	return &Template{tmpl: tmpl}, nil
+}
+
+// Execute renders the template for data, followed by a new line
+func (t *Template) Execute(w io.Writer, data interface{}) error {
+	text, err := t.String(data)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, text+"\n")
+	return err
+}
+
+// String renders the template for data, without a new line
+func (t *Template) String(data interface{}) (string, error) {
+	var b strings.Builder
+	if err := t.tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("unable to render --format template: %w", err)
+	}
+	return b.String(), nil
+}
+
+func colorText(name string, value interface{}) (string, error) {
+	c, ok := colors[strings.ToLower(name)]
+	if !ok {
+		return "", fmt.Errorf("unknown color %q", name)
+	}
+	return c.Apply(fmt.Sprint(value)), nil
+}
+
+func trunc(length int, value interface{}) string {
+	runes := []rune(fmt.Sprint(value))
+	if length < 0 || len(runes) <= length {
+		return string(runes)
+	}
+	return string(runes[:length])
+}
+
+func toJSON(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,71 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func Test_Template_Funcs(t *testing.T) {
+	data := struct {
+		Name     string
+		Instance string
+		Labels   map[string]string
+	}{
+		Name:     "figlet",
+		Instance: "figlet-7d9f5c6b8-x2x9z",
+		Labels:   map[string]string{"team": "a"},
+	}
+
+	cases := []struct {
+		format string
+		want   string
+	}{
+		{format: "{{.Instance | trunc 8}}", want: "figlet-7\n"},
+		{format: "{{trunc 20 .Name}}", want: "figlet\n"},
+		{format: "{{json .Labels}}", want: `{"team":"a"}` + "\n"},
+		{format: `{{color "red" .Name}}`, want: "\x1b[31mfiglet\x1b[0m\n"},
+	}
+
+	for _, tc := range cases {
+		tmpl, err := NewTemplate(tc.format, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var b bytes.Buffer
+		if err := tmpl.Execute(&b, data); err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != tc.want {
+			t.Errorf("%s: want %q, got: %q", tc.format, tc.want, b.String())
+		}
+	}
+}
+
+func Test_Template_Extra(t *testing.T) {
+	tmpl, err := NewTemplate("{{shout .}}", template.FuncMap{"shout": strings.ToUpper})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := tmpl.String("hi")
+	if err != nil || got != "HI" {
+		t.Fatalf("want %q, got: %q (%v)", "HI", got, err)
+	}
+}
+
+func Test_Template_Errors(t *testing.T) {
+	if _, err := NewTemplate("{{.Name", nil); err == nil {
+		t.Errorf("want a parse error")
+	}
+
+	tmpl, _ := NewTemplate(`{{color "plaid" .}}`, nil)
+	if _, err := tmpl.String("x"); err == nil {
+		t.Errorf("want an error for an unknown color")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/openfaas/faas-cli/config"
+	"github.com/openfaas/faas-cli/oidc"
 )
 
 // CLIAuth auth struct for the CLI
@@ -34,6 +35,11 @@ func (c *BearerToken) Set(req *http.Request) error {
 	return nil
 }
 
+// DefaultAuthTransport is used to refresh saved OIDC logins, so that
+// --tls-no-verify applies to the issuer as well as the gateway. A nil value
+// uses the default transport.
+var DefaultAuthTransport http.RoundTripper
+
 // NewCLIAuth returns a new CLI Auth
 func NewCLIAuth(token string, gateway string) (ClientAuth, error) {
 	authConfig, _ := config.LookupAuthConfig(gateway)
@@ -58,6 +64,20 @@ func NewCLIAuth(token string, gateway string) (ClientAuth, error) {
 
 	}
 
+	// A saved OIDC login is refreshed when it expires
+	if len(token) == 0 && oidc.IsRefreshable(authConfig) {
+		return oidc.NewTokenAuth(authConfig, DefaultAuthTransport), nil
+	}
+
+	// A machine login mints a new token when needed
+	if len(token) == 0 && oidc.IsMachineLogin(authConfig) {
+		machineAuth, err := oidc.NewMachineAuth(authConfig)
+		if err != nil {
+			return nil, err
+		}
+		return machineAuth, nil
+	}
+
 	// User specified token gets priority
 	if len(token) > 0 {
 		bearerToken = token
@@ -24,6 +24,8 @@ type Client struct {
 	GatewayURL *url.URL
 	//UserAgent user agent for the client
 	UserAgent string
+	//RetryPolicy controls retries when the gateway is unavailable
+	RetryPolicy RetryPolicy
 }
 
 // ClientAuth an interface for client authentication.
@@ -50,10 +52,11 @@ func NewClient(auth ClientAuth, gatewayURL string, transport http.RoundTripper,
 	}
 
 	return &Client{
-		ClientAuth: auth,
-		httpClient: client,
-		GatewayURL: baseURL,
-		UserAgent:  fmt.Sprintf("faas-cli/%s", version.BuildVersion()),
+		ClientAuth:  auth,
+		httpClient:  client,
+		GatewayURL:  baseURL,
+		UserAgent:   fmt.Sprintf("faas-cli/%s", version.BuildVersion()),
+		RetryPolicy: DefaultRetryPolicy,
 	}, nil
 }
 
@@ -81,7 +84,7 @@ func (c *Client) newRequest(method, path string, query url.Values, body io.Reade
 				return nil, err
 			}
 			bodyDebug = buf.String()
-			body = io.NopCloser(strings.NewReader(buf.String()))
+			body = strings.NewReader(buf.String())
 		}
 	}
 
@@ -128,8 +131,15 @@ func (c *Client) newRequest(method, path string, query url.Values, body io.Reade
 	return req, err
 }
 
-// doRequest perform an HTTP request with context
+// doRequest perform an HTTP request with context, idempotent requests are
+// retried according to the client's RetryPolicy
 func (c *Client) doRequest(ctx context.Context, req *http.Request) (*http.Response, error) {
+	return c.doRequestRetry(ctx, req, idempotent(req.Method))
+}
+
+// doRequestRetry perform an HTTP request with context, retrying it when
+// retry is set
+func (c *Client) doRequestRetry(ctx context.Context, req *http.Request, retry bool) (*http.Response, error) {
 	req = req.WithContext(ctx)
 
 	if val, ok := os.LookupEnv("OPENFAAS_DUMP_HTTP"); ok && val == "true" {
@@ -140,16 +150,7 @@ func (c *Client) doRequest(ctx context.Context, req *http.Request) (*http.Respon
 		fmt.Println(string(dump))
 	}
 
-	res, err := c.httpClient.Do(req)
-	if err != nil {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-		}
-	}
-
-	return res, err
+	return c.doWithRetry(ctx, req, retry)
 }
 
 func addQueryParams(u string, params map[string]string) (string, error) {
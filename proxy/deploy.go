@@ -62,6 +62,10 @@ func generateFuncStr(spec *DeployFunctionSpec) string {
 // a rolling update. Warnings are suppressed for the second API call (if required.)
 func (c *Client) DeployFunction(context context.Context, spec *DeployFunctionSpec) int {
 
+	// Output is collected and printed in a single write so that it stays
+	// readable when several functions are deployed at the same time.
+	var output string
+
 	rollingUpdateInfo := fmt.Sprintf("Function %s already exists, attempting rolling-update.", spec.FunctionName)
 	statusCode, deployOutput := c.deploy(context, spec, spec.Update)
 
@@ -70,10 +74,11 @@ func (c *Client) DeployFunction(context context.Context, spec *DeployFunctionSpe
 
 		statusCode, deployOutput = c.deploy(context, spec, false)
 	} else if statusCode == http.StatusOK {
-		fmt.Println(rollingUpdateInfo)
+		output += fmt.Sprintln(rollingUpdateInfo)
 	}
-	fmt.Println()
-	fmt.Println(deployOutput)
+	output += fmt.Sprintln()
+	output += fmt.Sprintln(deployOutput)
+	fmt.Print(output)
 	return statusCode
 }
 
@@ -153,7 +158,7 @@ func (c *Client) deploy(context context.Context, spec *DeployFunctionSpec, updat
 		return http.StatusInternalServerError, deployOutput
 	}
 
-	res, err := c.doRequest(context, request)
+	res, err := c.doRequestRetry(context, request, c.RetryPolicy.Deploy)
 
 	if err != nil {
 		deployOutput += fmt.Sprintln("Is OpenFaaS deployed? Do you need to specify the --gateway flag?")
@@ -47,7 +47,9 @@ func (c *Client) GetLogs(ctx context.Context, params logs.Request) (<-chan logs.
 				msg := logs.Message{}
 				err := decoder.Decode(&msg)
 				if err != nil {
-					log.Printf("cannot parse log results: %s\n", err.Error())
+					if ctx.Err() == nil {
+						log.Printf("cannot parse log results: %s\n", err.Error())
+					}
 					return
 				}
 				logStream <- msg
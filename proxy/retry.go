@@ -0,0 +1,220 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package proxy
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// maxRetryAfter caps the wait requested by a Retry-After header, so that a
+// misbehaving proxy can't stall the CLI indefinitely.
+const maxRetryAfter = time.Minute
+
+// RetryPolicy controls how requests to the gateway are retried after a
+// refused or reset connection, or a 429, 502, 503 or 504 response.
+type RetryPolicy struct {
+	// Retries is the number of attempts made after the first one fails,
+	// zero disables retries.
+	Retries int
+
+	// MinBackoff is the wait before the first retry, it doubles with each
+	// following retry up to MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// Deploy enables retries when deploying or updating functions, which are
+	// not idempotent so are only retried when asked for.
+	Deploy bool
+}
+
+// DefaultRetryPolicy is copied to each Client created by NewClient
+var DefaultRetryPolicy = RetryPolicy{
+	Retries:    3,
+	MinBackoff: 250 * time.Millisecond,
+	MaxBackoff: 5 * time.Second,
+}
+
+// Backoff returns the wait before the given retry, counting from zero, with
+// exponential growth and up to half of it taken off as jitter.
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	wait := p.MinBackoff
+	for i := 0; i < retry && wait < p.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+
+	if half := int64(wait / 2); half > 0 {
+		wait -= time.Duration(rand.Int64N(half + 1))
+	}
+	return wait
+}
+
+// retryable reports whether a request should be tried again after the given
+// result.
+func retryable(res *http.Response, err error) bool {
+	if err != nil {
+		return retryableError(err)
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryableError reports whether a request failed because the gateway was
+// unavailable for the moment, i.e. the connection was refused or reset, or a
+// temporary network error. Timeouts, certificate errors and hosts which are
+// not found are returned straight away, as trying again won't help.
+func retryableError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostname x509.HostnameError
+	var invalid x509.CertificateInvalidError
+	var verification *tls.CertificateVerificationError
+	if errors.As(err, &unknownAuthority) || errors.As(err, &hostname) ||
+		errors.As(err, &invalid) || errors.As(err, &verification) {
+		return false
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTemporary && !dnsErr.IsNotFound
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return false
+	}
+
+	// io.EOF is returned when the gateway closes the connection before it
+	// responds, such as when it is restarted.
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var temporary interface{ Temporary() bool }
+	return errors.As(err, &temporary) && temporary.Temporary()
+}
+
+// idempotent reports whether requests with the method are safe to retry
+// without being asked to.
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// retryAfter reads the Retry-After header given either in seconds or as an
+// HTTP date.
+func retryAfter(res *http.Response, now time.Time) (time.Duration, bool) {
+	if res == nil {
+		return 0, false
+	}
+
+	value := res.Header.Get("Retry-After")
+	if len(value) == 0 {
+		return 0, false
+	}
+
+	var wait time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	} else if when, err := http.ParseTime(value); err == nil {
+		wait = when.Sub(now)
+	} else {
+		return 0, false
+	}
+
+	if wait < 0 {
+		wait = 0
+	}
+	if wait > maxRetryAfter {
+		wait = maxRetryAfter
+	}
+	return wait, true
+}
+
+// doWithRetry sends the request, and when retry is set, sends it again
+// according to the client's RetryPolicy while the gateway is unavailable.
+func (c *Client) doWithRetry(ctx context.Context, req *http.Request, retry bool) (*http.Response, error) {
+	policy := c.RetryPolicy
+	if !retry || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		policy.Retries = 0
+	}
+
+	for attempt := 0; ; attempt++ {
+		res, err := c.httpClient.Do(req)
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			default:
+			}
+		}
+
+		if attempt >= policy.Retries || !retryable(res, err) {
+			return res, err
+		}
+
+		wait, ok := retryAfter(res, time.Now())
+		if !ok {
+			wait = policy.Backoff(attempt)
+		}
+
+		reason := ""
+		if err != nil {
+			reason = err.Error()
+		} else {
+			reason = res.Status
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		if os.Getenv("FAAS_DEBUG") == "1" {
+			fmt.Printf("Retrying %s %s in %s (%d/%d): %s\n", req.Method, req.URL.String(), wait.Round(time.Millisecond), attempt+1, policy.Retries, reason)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package proxy
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/openfaas/go-sdk/stack"
+)
+
+func newRetryTestClient(t *testing.T, url string, retries int) *Client {
+	t.Helper()
+
+	client, err := NewClient(NewTestAuth(nil), url, nil, &defaultCommandTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.RetryPolicy = RetryPolicy{
+		Retries:    retries,
+		MinBackoff: time.Millisecond,
+		MaxBackoff: 5 * time.Millisecond,
+	}
+	return client
+}
+
+func Test_ListFunctions_RetriesUnavailable(t *testing.T) {
+	var calls int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`[{"name":"figlet"}]`))
+	}))
+	defer s.Close()
+
+	client := newRetryTestClient(t, s.URL, 3)
+	functions, err := client.ListFunctions(context.Background(), "")
+	if err != nil {
+		t.Fatalf("want no error, got: %s", err)
+	}
+
+	if len(functions) != 1 || functions[0].Name != "figlet" {
+		t.Fatalf("want figlet, got: %v", functions)
+	}
+	if calls != 3 {
+		t.Fatalf("want 3 calls, got: %d", calls)
+	}
+}
+
+func Test_GetSystemInfo_GivesUpAfterRetries(t *testing.T) {
+	var calls int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer s.Close()
+
+	client := newRetryTestClient(t, s.URL, 2)
+	if _, err := client.GetSystemInfo(context.Background()); err == nil {
+		t.Fatalf("want an error")
+	}
+
+	if calls != 3 {
+		t.Fatalf("want 3 calls, got: %d", calls)
+	}
+}
+
+func Test_DeployFunction_RetriedOnlyWhenEnabled(t *testing.T) {
+	cases := []struct {
+		name      string
+		deploy    bool
+		wantCalls int32
+		wantCode  int
+	}{
+		{name: "not enabled", deploy: false, wantCalls: 1, wantCode: http.StatusServiceUnavailable},
+		{name: "enabled", deploy: true, wantCalls: 2, wantCode: http.StatusAccepted},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls int32
+			var bodies []string
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(body))
+
+				if atomic.AddInt32(&calls, 1) == 1 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer s.Close()
+
+			client := newRetryTestClient(t, s.URL, 2)
+			client.RetryPolicy.Deploy = tc.deploy
+
+			status := client.DeployFunction(context.Background(), &DeployFunctionSpec{
+				FunctionName: "figlet",
+				Image:        "ghcr.io/openfaas/figlet:latest",
+				Update:       true,
+				Replace:      false,
+				FunctionResourceRequest: FunctionResourceRequest{
+					Limits: &stack.FunctionResources{},
+				},
+			})
+
+			if status != tc.wantCode {
+				t.Fatalf("want status %d, got: %d", tc.wantCode, status)
+			}
+			if calls != tc.wantCalls {
+				t.Fatalf("want %d calls, got: %d", tc.wantCalls, calls)
+			}
+			for _, body := range bodies {
+				if body != bodies[0] || len(body) == 0 {
+					t.Fatalf("want the same body on each attempt, got: %q", bodies)
+				}
+			}
+		})
+	}
+}
+
+func Test_retryable(t *testing.T) {
+	dialErr := func(err error) error {
+		return &url.Error{Op: "Get", URL: "http://127.0.0.1:8080/system/functions", Err: &net.OpError{
+			Op:  "dial",
+			Net: "tcp",
+			Err: os.NewSyscallError("connect", err),
+		}}
+	}
+
+	cases := []struct {
+		title  string
+		status int
+		err    error
+		want   bool
+	}{
+		{title: "ok", status: http.StatusOK, want: false},
+		{title: "not found", status: http.StatusNotFound, want: false},
+		{title: "service unavailable", status: http.StatusServiceUnavailable, want: true},
+		{title: "too many requests", status: http.StatusTooManyRequests, want: true},
+		{title: "connection refused", err: dialErr(syscall.ECONNREFUSED), want: true},
+		{title: "connection reset", err: dialErr(syscall.ECONNRESET), want: true},
+		{title: "connection closed", err: &url.Error{Op: "Get", Err: io.EOF}, want: true},
+		{title: "temporary DNS failure", err: &net.DNSError{Err: "server misbehaving", Name: "gateway", IsTemporary: true}, want: true},
+		{title: "deadline exceeded", err: &url.Error{Op: "Get", Err: context.DeadlineExceeded}, want: false},
+		{title: "unknown certificate authority", err: &url.Error{Op: "Get", Err: &tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}}}, want: false},
+		{title: "certificate hostname mismatch", err: &url.Error{Op: "Get", Err: x509.HostnameError{Host: "gateway"}}, want: false},
+		{title: "unknown host", err: &url.Error{Op: "Get", Err: &net.DNSError{Err: "no such host", Name: "gateway", IsNotFound: true}}, want: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			var res *http.Response
+			if tc.err == nil {
+				res = &http.Response{StatusCode: tc.status}
+			}
+
+			if got := retryable(res, tc.err); got != tc.want {
+				t.Fatalf("want retryable to be %v, got: %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func Test_retryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name   string
+		header string
+		want   time.Duration
+		wantOK bool
+	}{
+		{name: "not set", header: "", wantOK: false},
+		{name: "seconds", header: "2", want: 2 * time.Second, wantOK: true},
+		{name: "http date", header: now.Add(5 * time.Second).Format(http.TimeFormat), want: 5 * time.Second, wantOK: true},
+		{name: "capped", header: "3600", want: maxRetryAfter, wantOK: true},
+		{name: "invalid", header: "soon", wantOK: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := &http.Response{Header: http.Header{}}
+			if len(tc.header) > 0 {
+				res.Header.Set("Retry-After", tc.header)
+			}
+
+			got, ok := retryAfter(res, now)
+			if ok != tc.wantOK || got != tc.want {
+				t.Fatalf("want %s, %v, got: %s, %v", tc.want, tc.wantOK, got, ok)
+			}
+		})
+	}
+}
+
+func Test_RetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	cases := []struct {
+		retry int
+		max   time.Duration
+	}{
+		{retry: 0, max: 100 * time.Millisecond},
+		{retry: 1, max: 200 * time.Millisecond},
+		{retry: 2, max: 400 * time.Millisecond},
+		{retry: 10, max: time.Second},
+	}
+
+	for _, tc := range cases {
+		for i := 0; i < 20; i++ {
+			got := policy.Backoff(tc.retry)
+			if got < tc.max/2 || got > tc.max {
+				t.Fatalf("retry %d: want a backoff between %s and %s, got: %s", tc.retry, tc.max/2, tc.max, got)
+			}
+		}
+	}
+}
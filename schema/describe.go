@@ -8,8 +8,8 @@ import "github.com/openfaas/faas-provider/types"
 // FunctionDescription information related to a function
 type FunctionDescription struct {
 	types.FunctionStatus
-	Status          string
-	InvocationCount int
-	URL             string
-	AsyncURL        string
+	Status          string `json:"status"`
+	InvocationCount int    `json:"invocationCount"`
+	URL             string `json:"url"`
+	AsyncURL        string `json:"asyncUrl"`
 }
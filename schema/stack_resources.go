@@ -0,0 +1,104 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package schema
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/drone/envsubst"
+	"gopkg.in/yaml.v3"
+)
+
+// StackResources are the namespaces and secrets declared at the top level of
+// a stack file, which need to exist before its functions are deployed.
+type StackResources struct {
+	Namespaces map[string]StackNamespace `yaml:"namespaces,omitempty"`
+	Secrets    map[string]StackSecret    `yaml:"secrets,omitempty"`
+}
+
+// StackNamespace is a namespace declared in a stack file
+type StackNamespace struct {
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+// StackSecret is a secret declared in a stack file. The value is read from
+// FromFile or FromEnv, or when neither is set, from a file with the secret's
+// name in the local .secrets folder.
+type StackSecret struct {
+	Namespace string `yaml:"namespace,omitempty"`
+	FromFile  string `yaml:"from_file,omitempty"`
+	FromEnv   string `yaml:"from_env,omitempty"`
+}
+
+// ParseStackResources reads the namespaces and secrets sections of a stack
+// file given as a path or URL.
+func ParseStackResources(yamlFile string, substituteEnv bool) (*StackResources, error) {
+	var fileData []byte
+
+	urlParsed, err := url.Parse(yamlFile)
+	if err == nil && len(urlParsed.Scheme) > 0 {
+		fileData, err = fetchStackFile(urlParsed)
+	} else {
+		fileData, err = os.ReadFile(yamlFile)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseStackResourcesData(fileData, substituteEnv)
+}
+
+// ParseStackResourcesData reads the namespaces and secrets sections of a
+// stack file.
+func ParseStackResourcesData(fileData []byte, substituteEnv bool) (*StackResources, error) {
+	if substituteEnv {
+		parsed, err := envsubst.Parse(string(fileData))
+		if err != nil {
+			return nil, err
+		}
+
+		res, err := parsed.Execute(func(input string) string {
+			return os.Getenv(input)
+		})
+		if err != nil {
+			return nil, err
+		}
+		fileData = []byte(res)
+	}
+
+	resources := StackResources{}
+	if err := yaml.Unmarshal(fileData, &resources); err != nil {
+		return nil, fmt.Errorf("unable to parse namespaces and secrets from stack file: %w", err)
+	}
+
+	for name, secret := range resources.Secrets {
+		if len(secret.FromFile) > 0 && len(secret.FromEnv) > 0 {
+			return nil, fmt.Errorf("secret %s: give only one of from_file or from_env", name)
+		}
+	}
+
+	return &resources, nil
+}
+
+func fetchStackFile(address *url.URL) ([]byte, error) {
+	client := http.Client{Timeout: 120 * time.Second}
+
+	res, err := client.Get(address.String())
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d fetching %s", res.StatusCode, address.String())
+	}
+
+	return io.ReadAll(res.Body)
+}
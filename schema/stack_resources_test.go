@@ -0,0 +1,91 @@
+// Copyright (c) OpenFaaS Author(s) 2025. All rights reserved.
+// Licensed under the MIT license. See LICENSE file in the project root for full license information.
+
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+const stackWithResources = `version: 1.0
+provider:
+  name: openfaas
+  gateway: http://127.0.0.1:8080
+namespaces:
+  staging-fn:
+    labels:
+      team: ${TEAM}
+    annotations:
+      openfaas: "1"
+secrets:
+  api-key:
+    namespace: staging-fn
+    from_env: API_KEY
+  db-password:
+    from_file: ./db-password.txt
+  webhook-token: {}
+functions:
+  figlet:
+    image: ghcr.io/openfaas/figlet:latest
+`
+
+func Test_ParseStackResourcesData(t *testing.T) {
+	t.Setenv("TEAM", "payments")
+
+	resources, err := ParseStackResourcesData([]byte(stackWithResources), true)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	ns, ok := resources.Namespaces["staging-fn"]
+	if !ok {
+		t.Fatalf("want namespace staging-fn, got: %v", resources.Namespaces)
+	}
+	if got := ns.Labels["team"]; got != "payments" {
+		t.Errorf("want label team=payments, got: %q", got)
+	}
+	if got := ns.Annotations["openfaas"]; got != "1" {
+		t.Errorf("want annotation openfaas=1, got: %q", got)
+	}
+
+	if len(resources.Secrets) != 3 {
+		t.Fatalf("want 3 secrets, got: %d", len(resources.Secrets))
+	}
+	if got := resources.Secrets["api-key"]; got.Namespace != "staging-fn" || got.FromEnv != "API_KEY" {
+		t.Errorf("unexpected api-key secret: %+v", got)
+	}
+	if got := resources.Secrets["db-password"].FromFile; got != "./db-password.txt" {
+		t.Errorf("want from_file ./db-password.txt, got: %q", got)
+	}
+	if _, ok := resources.Secrets["webhook-token"]; !ok {
+		t.Errorf("want webhook-token secret")
+	}
+}
+
+func Test_ParseStackResourcesData_NoSections(t *testing.T) {
+	resources, err := ParseStackResourcesData([]byte("version: 1.0\nfunctions: {}\n"), false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(resources.Namespaces) != 0 || len(resources.Secrets) != 0 {
+		t.Errorf("want no resources, got: %+v", resources)
+	}
+}
+
+func Test_ParseStackResourcesData_BothSources(t *testing.T) {
+	data := `secrets:
+  api-key:
+    from_env: API_KEY
+    from_file: ./api-key.txt
+`
+	_, err := ParseStackResourcesData([]byte(data), false)
+	if err == nil {
+		t.Fatalf("want error for a secret with both from_env and from_file")
+	}
+
+	if !strings.Contains(err.Error(), "api-key") {
+		t.Errorf("want error to name the secret, got: %s", err)
+	}
+}